@@ -0,0 +1,146 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/tektite-io/upwind-go-sdk/sdk"
+)
+
+// handleConfigCommand dispatches the "config" command's subcommands. It
+// takes no client, since init/use/list only touch the local profile
+// file and shouldn't require OAuth2 credentials to already be valid.
+func handleConfigCommand(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: config <init|use|list>")
+	}
+
+	switch args[0] {
+	case "init":
+		return handleConfigInit(args[1:])
+	case "use":
+		return handleConfigUse(args[1:])
+	case "list":
+		return handleConfigList(args[1:])
+	default:
+		return fmt.Errorf("unknown config subcommand: %s", args[0])
+	}
+}
+
+func profileFilePath() (string, error) {
+	if path := getFlagValue("--profile-file"); path != "" {
+		return path, nil
+	}
+	return sdk.DefaultProfilePath()
+}
+
+// handleConfigInit writes a new profile file seeded with a single
+// profile (named by --profile, default "default") built from
+// --client-id, --client-secret, --organization-id, and --region. It
+// refuses to overwrite an existing file.
+func handleConfigInit(args []string) error {
+	path, err := profileFilePath()
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("profile file already exists at %s", path)
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	name := getFlagValue("--profile")
+	if name == "" {
+		name = "default"
+	}
+
+	cfg := &sdk.Config{}
+	if v := getFlagValue("--client-id"); v != "" {
+		cfg.ClientID = v
+	}
+	if v := getFlagValue("--client-secret"); v != "" {
+		cfg.ClientSecret = v
+	}
+	if v := getFlagValue("--organization-id"); v != "" {
+		cfg.OrganizationID = v
+	}
+	if v := getFlagValue("--region"); v != "" {
+		cfg.Region = sdk.Region(strings.ToUpper(v))
+	}
+
+	pf := &sdk.ProfileFile{
+		Current:  name,
+		Profiles: map[string]*sdk.Config{name: cfg},
+	}
+	if err := pf.WriteFile(path); err != nil {
+		return err
+	}
+
+	fmt.Printf("Wrote profile %q to %s\n", name, path)
+	return nil
+}
+
+// handleConfigUse switches the profile file's Current profile.
+func handleConfigUse(args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("usage: config use <profile>")
+	}
+	name := args[0]
+
+	path, err := profileFilePath()
+	if err != nil {
+		return err
+	}
+
+	pf, err := sdk.LoadProfileFile(path)
+	if err != nil {
+		return err
+	}
+	if _, ok := pf.Profiles[name]; !ok {
+		return fmt.Errorf("profile %q not found in %s", name, path)
+	}
+
+	pf.Current = name
+	if err := pf.WriteFile(path); err != nil {
+		return err
+	}
+
+	fmt.Printf("Switched to profile %q\n", name)
+	return nil
+}
+
+// handleConfigList prints every profile in the profile file, marking
+// the current one with a "*".
+func handleConfigList(args []string) error {
+	path, err := profileFilePath()
+	if err != nil {
+		return err
+	}
+
+	pf, err := sdk.LoadProfileFile(path)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(pf.Profiles))
+	for name := range pf.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		marker := "  "
+		if name == pf.Current {
+			marker = "* "
+		}
+		fmt.Printf("%s%s\n", marker, name)
+	}
+	return nil
+}
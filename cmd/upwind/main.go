@@ -6,11 +6,12 @@ package main
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"os/signal"
+	"reflect"
 	"syscall"
 
 	"github.com/tektite-io/upwind-go-sdk/sdk"
@@ -42,6 +43,15 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Handle config command (init/use/list manage the local profile
+	// file and don't need a client)
+	if command == "config" {
+		if err := handleConfigCommand(os.Args[2:]); err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+		os.Exit(0)
+	}
+
 	// Create client
 	client, err := createClient()
 	if err != nil {
@@ -68,6 +78,17 @@ func main() {
 
 	// Execute command
 	if err := executeCommand(ctx, client, command, os.Args[2:]); err != nil {
+		var problem *sdk.ProblemError
+		if errors.As(err, &problem) {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", problem.Title)
+			if problem.Detail != "" {
+				fmt.Fprintf(os.Stderr, "  %s\n", problem.Detail)
+			}
+			if problem.Instance != "" {
+				fmt.Fprintf(os.Stderr, "  instance: %s\n", problem.Instance)
+			}
+			os.Exit(1)
+		}
 		log.Fatalf("Error: %v", err)
 	}
 }
@@ -79,8 +100,22 @@ func createClient() (*sdk.Client, error) {
 		return sdk.NewClientFromFile(configFile)
 	}
 
-	// Otherwise, use environment variables
-	return sdk.NewClientFromEnv()
+	// Otherwise, layer a profile file (see "config init") under
+	// environment variables, following the documented precedence chain
+	// on sdk.ConfigLoader.
+	loader := sdk.NewConfigLoader()
+	if err := loader.Layer(sdk.ProfileFileSource(getFlagValue("--profile-file"), getFlagValue("--profile"))); err != nil {
+		return nil, fmt.Errorf("loading profile: %w", err)
+	}
+	if err := loader.Layer(sdk.EnvConfigSource()); err != nil {
+		return nil, fmt.Errorf("loading environment: %w", err)
+	}
+
+	cfg, err := loader.Resolve()
+	if err != nil {
+		return nil, err
+	}
+	return sdk.NewClient(cfg)
 }
 
 func executeCommand(ctx context.Context, client *sdk.Client, command string, args []string) error {
@@ -103,6 +138,8 @@ func executeCommand(ctx context.Context, client *sdk.Client, command string, arg
 		return handleWorkflows(ctx, client, args)
 	case "integration-webhooks", "webhooks":
 		return handleIntegrationWebhooks(ctx, client, args)
+	case "shell":
+		return handleShell(ctx, client)
 	default:
 		return fmt.Errorf("unknown command: %s", command)
 	}
@@ -117,7 +154,7 @@ func handleVulnerabilityFindings(ctx context.Context, client *sdk.Client, args [
 		if err != nil {
 			return err
 		}
-		return printJSON(finding)
+		return printResult(finding)
 	}
 
 	// List findings
@@ -137,8 +174,8 @@ func handleVulnerabilityFindings(ctx context.Context, client *sdk.Client, args [
 		query.Exploitable = &exploitable
 	}
 
-	findingsCh, errCh := client.ListVulnerabilityFindings(ctx, query)
-	return streamAndPrintJSON(ctx, findingsCh, errCh)
+	findingsCh, errCh := client.ListVulnerabilityFindingsPaginator(*query).Stream(ctx)
+	return streamResult(ctx, findingsCh, errCh)
 }
 
 func handleConfigurationFindings(ctx context.Context, client *sdk.Client, args []string) error {
@@ -146,11 +183,11 @@ func handleConfigurationFindings(ctx context.Context, client *sdk.Client, args [
 		if len(args) < 2 {
 			return fmt.Errorf("usage: configuration-findings get <finding-id>")
 		}
-		finding, err := client.GetConfigurationFinding(ctx, args[1], hasFlag("--include-tags"))
+		finding, err := client.GetConfigurationFinding(ctx, nil, args[1], hasFlag("--include-tags"))
 		if err != nil {
 			return err
 		}
-		return printJSON(finding)
+		return printResult(finding)
 	}
 
 	// List findings
@@ -165,8 +202,8 @@ func handleConfigurationFindings(ctx context.Context, client *sdk.Client, args [
 		query.FrameworkID = frameworkID
 	}
 
-	findingsCh, errCh := client.ListConfigurationFindings(ctx, query)
-	return streamAndPrintJSON(ctx, findingsCh, errCh)
+	findingsCh, errCh := client.ListConfigurationFindings(ctx, nil, query)
+	return streamResult(ctx, findingsCh, errCh)
 }
 
 func handleThreatDetections(ctx context.Context, client *sdk.Client, args []string) error {
@@ -178,7 +215,7 @@ func handleThreatDetections(ctx context.Context, client *sdk.Client, args []stri
 		if err != nil {
 			return err
 		}
-		return printJSON(detection)
+		return printResult(detection)
 	}
 
 	if len(args) > 0 && args[0] == "archive" {
@@ -190,7 +227,7 @@ func handleThreatDetections(ctx context.Context, client *sdk.Client, args []stri
 			return err
 		}
 		fmt.Fprintln(os.Stderr, "Detection archived successfully")
-		return printJSON(detection)
+		return printResult(detection)
 	}
 
 	// List detections
@@ -209,7 +246,7 @@ func handleThreatDetections(ctx context.Context, client *sdk.Client, args []stri
 	if err != nil {
 		return err
 	}
-	return printJSON(detections)
+	return printResult(detections)
 }
 
 func handleThreatEvents(ctx context.Context, client *sdk.Client, args []string) error {
@@ -225,7 +262,7 @@ func handleThreatEvents(ctx context.Context, client *sdk.Client, args []string)
 	if err != nil {
 		return err
 	}
-	return printJSON(events)
+	return printResult(events)
 }
 
 func handleThreatPolicies(ctx context.Context, client *sdk.Client, args []string) error {
@@ -234,7 +271,7 @@ func handleThreatPolicies(ctx context.Context, client *sdk.Client, args []string
 	if err != nil {
 		return err
 	}
-	return printJSON(policies)
+	return printResult(policies)
 }
 
 func handleApiEndpoints(ctx context.Context, client *sdk.Client, args []string) error {
@@ -250,7 +287,7 @@ func handleApiEndpoints(ctx context.Context, client *sdk.Client, args []string)
 	}
 
 	endpointsCh, errCh := client.ListApiEndpoints(ctx, query)
-	return streamAndPrintJSON(ctx, endpointsCh, errCh)
+	return streamResult(ctx, endpointsCh, errCh)
 }
 
 func handleSbomPackages(ctx context.Context, client *sdk.Client, args []string) error {
@@ -262,7 +299,7 @@ func handleSbomPackages(ctx context.Context, client *sdk.Client, args []string)
 		if err != nil {
 			return err
 		}
-		return printJSON(pkg)
+		return printResult(pkg)
 	}
 
 	// List packages
@@ -278,7 +315,7 @@ func handleSbomPackages(ctx context.Context, client *sdk.Client, args []string)
 	if err != nil {
 		return err
 	}
-	return printJSON(packages)
+	return printResult(packages)
 }
 
 func handleWorkflows(ctx context.Context, client *sdk.Client, args []string) error {
@@ -290,7 +327,7 @@ func handleWorkflows(ctx context.Context, client *sdk.Client, args []string) err
 		if err != nil {
 			return err
 		}
-		return printJSON(workflow)
+		return printResult(workflow)
 	}
 
 	// List workflows
@@ -298,62 +335,79 @@ func handleWorkflows(ctx context.Context, client *sdk.Client, args []string) err
 	if err != nil {
 		return err
 	}
-	return printJSON(workflows)
+	return printResult(workflows)
 }
 
 func handleIntegrationWebhooks(ctx context.Context, client *sdk.Client, args []string) error {
+	if len(args) > 0 && args[0] == "serve" {
+		return handleWebhooksServe(ctx, args[1:])
+	}
+
 	vendor := getFlagValue("--vendor")
 	webhooks, err := client.ListIntegrationWebhooks(ctx, vendor)
 	if err != nil {
 		return err
 	}
-	return printJSON(webhooks)
+	return printResult(webhooks)
 }
 
 // Helper functions
 
-func printJSON(v interface{}) error {
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(v)
-}
+// printResult writes v through the --output-selected OutputWriter. A
+// slice is written one element at a time so csv/table/ndjson formats
+// see individual rows rather than one row containing the whole slice.
+func printResult(v interface{}) error {
+	w, err := newOutputWriterFromFlags(os.Stdout)
+	if err != nil {
+		return err
+	}
 
-func streamAndPrintJSON[T any](ctx context.Context, itemsCh <-chan T, errCh <-chan error) error {
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Slice {
+		for i := 0; i < rv.Len(); i++ {
+			if err := w.Write(rv.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+	} else if err := w.Write(v); err != nil {
+		return err
+	}
 
-	// Start JSON array
-	fmt.Println("[")
-	first := true
+	return w.Close()
+}
+
+// streamResult drains itemsCh through the --output-selected
+// OutputWriter as items arrive, honoring ctx cancellation, so a large
+// paginated result streams incrementally instead of buffering in memory.
+func streamResult[T any](ctx context.Context, itemsCh <-chan T, errCh <-chan error) error {
+	w, err := newOutputWriterFromFlags(os.Stdout)
+	if err != nil {
+		return err
+	}
 
 	for {
 		select {
 		case item, ok := <-itemsCh:
 			if !ok {
-				// Channel closed
-				fmt.Println("]")
-				// Check for errors
 				select {
 				case err := <-errCh:
-					return err
+					if err != nil {
+						return err
+					}
 				default:
-					return nil
 				}
+				return w.Close()
 			}
-			if !first {
-				fmt.Println(",")
-			}
-			first = false
-			if err := encoder.Encode(item); err != nil {
-				return fmt.Errorf("encoding JSON: %w", err)
+			if err := w.Write(item); err != nil {
+				return err
 			}
 		case err := <-errCh:
 			if err != nil {
-				fmt.Println("]")
+				_ = w.Close()
 				return err
 			}
 		case <-ctx.Done():
-			fmt.Println("]")
+			_ = w.Close()
 			return ctx.Err()
 		}
 	}
@@ -393,16 +447,29 @@ COMMANDS:
     sbom-packages, packages                List or get SBOM packages
     workflows                              List or get workflows
     integration-webhooks, webhooks         List integration webhooks
+    config                                 Manage local profile file (init, use, list)
+    shell                                  Interactive REPL reusing one authenticated client
     version                                Show version information
     help                                   Show this help message
 
 SUBCOMMANDS:
     get <id>                              Get a specific resource by ID
     archive <id>                          Archive a resource (threat detections only)
+    config init                           Create a new profile file
+    config use <profile>                  Switch the active profile
+    config list                           List profiles in the profile file
+    webhooks serve                        Run a signed webhook receiver, dumping deliveries
 
 GLOBAL OPTIONS:
     --config <file>                       Path to config file (JSON format)
+    --profile <name>                      Profile to select from the profile file (default: its "current")
+    --profile-file <file>                 Path to the profile file (default: ~/.upwind/config.yaml)
     --verbose, -v                         Enable verbose logging
+    --output, -o <format>                 Output format: json (default), ndjson, yaml, csv, table, template
+    --csv-fields <a,b,c>                  Dotted-path field selectors for --output csv (default: all top-level fields)
+    --template <text>                     Go text/template string for --output template
+    --addr <addr>                         Address to bind for "webhooks serve" (default: :8080)
+    --secret-file <file>                  JSON file with a "secret" field, for "webhooks serve"
     -h, --help                            Show help message
 
 FILTER OPTIONS (varies by command):
@@ -434,6 +501,7 @@ ENVIRONMENT VARIABLES:
     UPWIND_MAX_CONCURRENCY                Maximum concurrent requests (default: 10)
     UPWIND_PAGE_SIZE                      Default page size (default: 100)
     UPWIND_RATE_LIMIT                     Requests per second limit (default: 10)
+    UPWIND_PROFILE                        Profile to select from the profile file (optional)
 
 EXAMPLES:
     # List all vulnerability findings with high severity
@@ -454,6 +522,28 @@ EXAMPLES:
     # Use a config file instead of environment variables
     upwind --config config.json vulnerability-findings
 
+    # Stream findings as newline-delimited JSON for a shell pipeline
+    upwind vulnerability-findings --output ndjson | jq .id
+
+    # Render threat detections as a table
+    upwind threat-detections --output table
+
+    # Export selected fields as CSV
+    upwind sbom-packages --output csv --csv-fields name,version,framework
+
+    # Create a profile file and switch between profiles
+    upwind config init --profile staging --client-id ... --client-secret ... --organization-id ...
+    upwind config use staging
+    upwind vulnerability-findings --profile production
+
+    # Explore interactively, reusing one authenticated connection
+    upwind shell
+    upwind> threats --severity CRITICAL
+    upwind> \pipe jq '.[] | .id'
+
+    # Run a signed webhook receiver and stream deliveries as ndjson
+    upwind webhooks serve --addr :8080 --secret-file secrets.json --output ndjson
+
 For more information, visit: https://docs.upwind.io
 `, sdk.Version)
 }
@@ -0,0 +1,332 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// OutputWriter streams decoded API values to an underlying writer in a
+// chosen format. Write is called once per resource, so a streaming
+// command never has to buffer an entire result set in memory; Close
+// flushes any format-specific trailer (a closing "]" for json, a flush
+// for csv).
+type OutputWriter interface {
+	Write(v interface{}) error
+	Close() error
+}
+
+// newOutputWriterFromFlags builds the OutputWriter selected by the
+// --output/-o flag (json, ndjson, yaml, csv, table, or template),
+// defaulting to json. csv honors --csv-fields as a comma-separated list
+// of dotted-path field selectors (e.g. "id,metadata.name"), falling
+// back to a value's top-level keys, sorted, when omitted. template
+// requires --template, a Go text/template string executed once per
+// value.
+func newOutputWriterFromFlags(w io.Writer) (OutputWriter, error) {
+	format := getFlagValue("--output")
+	if format == "" {
+		format = getFlagValue("-o")
+	}
+	if format == "" {
+		format = "json"
+	}
+
+	switch format {
+	case "json":
+		return newJSONWriter(w, false), nil
+	case "ndjson":
+		return newJSONWriter(w, true), nil
+	case "yaml":
+		return newYAMLWriter(w), nil
+	case "csv":
+		var fields []string
+		if raw := getFlagValue("--csv-fields"); raw != "" {
+			fields = strings.Split(raw, ",")
+		}
+		return newCSVWriter(w, fields), nil
+	case "table":
+		return newTableWriter(w, isTerminal(w)), nil
+	case "template":
+		tmplStr := getFlagValue("--template")
+		if tmplStr == "" {
+			return nil, fmt.Errorf("--template is required when --output is template")
+		}
+		return newTemplateWriter(w, tmplStr)
+	default:
+		return nil, fmt.Errorf("unknown output format %q (want json, ndjson, yaml, csv, table, or template)", format)
+	}
+}
+
+// jsonWriter emits either a single JSON array (one Encode call per
+// element, brackets written incrementally around them) or, in ndjson
+// mode, one compact object per line with no enclosing array - the
+// preferred shape for a shell pipeline since it never buffers the full
+// result set.
+type jsonWriter struct {
+	enc       *json.Encoder
+	w         io.Writer
+	ndjson    bool
+	wroteOpen bool
+}
+
+func newJSONWriter(w io.Writer, ndjson bool) *jsonWriter {
+	enc := json.NewEncoder(w)
+	if !ndjson {
+		enc.SetIndent("", "  ")
+	}
+	return &jsonWriter{enc: enc, w: w, ndjson: ndjson}
+}
+
+// Write implements OutputWriter.
+func (jw *jsonWriter) Write(v interface{}) error {
+	if jw.ndjson {
+		return jw.enc.Encode(v)
+	}
+	if !jw.wroteOpen {
+		fmt.Fprintln(jw.w, "[")
+		jw.wroteOpen = true
+	} else {
+		fmt.Fprintln(jw.w, ",")
+	}
+	return jw.enc.Encode(v)
+}
+
+// Close implements OutputWriter.
+func (jw *jsonWriter) Close() error {
+	if jw.ndjson {
+		return nil
+	}
+	if !jw.wroteOpen {
+		fmt.Fprintln(jw.w, "[]")
+		return nil
+	}
+	fmt.Fprintln(jw.w, "]")
+	return nil
+}
+
+// yamlWriter emits one YAML document per value; yaml.Encoder inserts
+// the "---" document separator automatically after the first.
+type yamlWriter struct {
+	enc *yaml.Encoder
+}
+
+func newYAMLWriter(w io.Writer) *yamlWriter {
+	return &yamlWriter{enc: yaml.NewEncoder(w)}
+}
+
+// Write implements OutputWriter.
+func (yw *yamlWriter) Write(v interface{}) error {
+	return yw.enc.Encode(v)
+}
+
+// Close implements OutputWriter.
+func (yw *yamlWriter) Close() error {
+	return yw.enc.Close()
+}
+
+// csvWriter flattens each value to a row via dotted-path field
+// selectors. The header is written from the first value when fields is
+// empty, using its top-level keys in sorted order.
+type csvWriter struct {
+	w           *csv.Writer
+	fields      []string
+	wroteHeader bool
+}
+
+func newCSVWriter(w io.Writer, fields []string) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w), fields: fields}
+}
+
+// Write implements OutputWriter.
+func (cw *csvWriter) Write(v interface{}) error {
+	data, err := toGenericMap(v)
+	if err != nil {
+		return err
+	}
+
+	if !cw.wroteHeader {
+		if len(cw.fields) == 0 {
+			cw.fields = sortedKeys(data)
+		}
+		if err := cw.w.Write(cw.fields); err != nil {
+			return fmt.Errorf("writing csv header: %w", err)
+		}
+		cw.wroteHeader = true
+	}
+
+	row := make([]string, len(cw.fields))
+	for i, field := range cw.fields {
+		row[i] = fmt.Sprint(dottedLookup(data, field))
+	}
+	if err := cw.w.Write(row); err != nil {
+		return fmt.Errorf("writing csv row: %w", err)
+	}
+	return nil
+}
+
+// Close implements OutputWriter.
+func (cw *csvWriter) Close() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+// tableColWidth is the fixed column width used by tableWriter. A fixed
+// width (rather than one computed from the whole result set) lets the
+// table print incrementally instead of buffering every row first.
+const tableColWidth = 28
+
+// tableWriter renders auto-detected columns (the first value's
+// top-level keys, sorted) as a space-padded table, truncating long
+// cells and bolding the header when color is true.
+type tableWriter struct {
+	w           io.Writer
+	color       bool
+	headers     []string
+	wroteHeader bool
+}
+
+func newTableWriter(w io.Writer, color bool) *tableWriter {
+	return &tableWriter{w: w, color: color}
+}
+
+// Write implements OutputWriter.
+func (tw *tableWriter) Write(v interface{}) error {
+	data, err := toGenericMap(v)
+	if err != nil {
+		return err
+	}
+
+	if !tw.wroteHeader {
+		tw.headers = sortedKeys(data)
+		tw.writeRow(tw.headers, tw.color)
+		tw.wroteHeader = true
+	}
+
+	row := make([]string, len(tw.headers))
+	for i, header := range tw.headers {
+		row[i] = truncateCell(fmt.Sprint(dottedLookup(data, header)))
+	}
+	tw.writeRow(row, false)
+	return nil
+}
+
+func (tw *tableWriter) writeRow(cells []string, bold bool) {
+	padded := make([]string, len(cells))
+	for i, cell := range cells {
+		padded[i] = fmt.Sprintf("%-*s", tableColWidth, cell)
+	}
+	line := strings.TrimRight(strings.Join(padded, ""), " ")
+	if bold {
+		line = "\x1b[1m" + line + "\x1b[0m"
+	}
+	fmt.Fprintln(tw.w, line)
+}
+
+// Close implements OutputWriter.
+func (tw *tableWriter) Close() error {
+	return nil
+}
+
+func truncateCell(s string) string {
+	if len(s) <= tableColWidth-2 {
+		return s
+	}
+	return s[:tableColWidth-5] + "..."
+}
+
+// templateWriter executes a user-supplied text/template once per value,
+// followed by a newline.
+type templateWriter struct {
+	w    io.Writer
+	tmpl *template.Template
+}
+
+func newTemplateWriter(w io.Writer, tmplStr string) (*templateWriter, error) {
+	tmpl, err := template.New("output").Parse(tmplStr)
+	if err != nil {
+		return nil, fmt.Errorf("parsing template: %w", err)
+	}
+	return &templateWriter{w: w, tmpl: tmpl}, nil
+}
+
+// Write implements OutputWriter.
+func (tw *templateWriter) Write(v interface{}) error {
+	if err := tw.tmpl.Execute(tw.w, v); err != nil {
+		return fmt.Errorf("executing template: %w", err)
+	}
+	fmt.Fprintln(tw.w)
+	return nil
+}
+
+// Close implements OutputWriter.
+func (tw *templateWriter) Close() error {
+	return nil
+}
+
+// toGenericMap round-trips v through encoding/json to get a
+// map[string]interface{} view usable by the csv and table writers'
+// dotted-path lookups, regardless of v's concrete struct type.
+func toGenericMap(v interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("encoding value: %w", err)
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("value is not a JSON object: %w", err)
+	}
+	return m, nil
+}
+
+// dottedLookup resolves a dotted field selector like "metadata.name"
+// against a nested map produced by toGenericMap, returning nil if any
+// segment is missing or not itself a map.
+func dottedLookup(m map[string]interface{}, path string) interface{} {
+	var cur interface{} = m
+	for _, part := range strings.Split(path, ".") {
+		asMap, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		cur = asMap[part]
+	}
+	return cur
+}
+
+// sortedKeys returns m's keys in sorted order, for deterministic
+// auto-detected csv/table columns.
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// isTerminal reports whether w is a character device, so table output
+// can bold its header only when stdout is an interactive terminal and
+// not a pipe or file redirect.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,242 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/chzyer/readline"
+	"github.com/tektite-io/upwind-go-sdk/sdk"
+)
+
+// shellCommands lists the top-level commands offered by the interactive
+// shell's tab completion, mirroring executeCommand's switch.
+var shellCommands = []string{
+	"vulnerability-findings", "vulns",
+	"configuration-findings", "config-findings",
+	"threat-detections", "threats",
+	"threat-events",
+	"threat-policies",
+	"api-endpoints",
+	"sbom-packages", "packages",
+	"workflows",
+	"integration-webhooks", "webhooks",
+	"help", "exit", "quit",
+}
+
+// shellFlags lists common flags offered by tab completion. Not every
+// flag applies to every command; the shell doesn't try to scope
+// completion per-command, the same tradeoff getFlagValue/hasFlag
+// already make by reading os.Args globally rather than per-command.
+var shellFlags = []string{
+	"--severity", "--status", "--category", "--type", "--image-name",
+	"--framework-id", "--managed-by", "--method", "--domain",
+	"--auth-state", "--package-name", "--framework", "--vendor",
+	"--in-use", "--exploitable", "--include-tags",
+	"--output", "--csv-fields", "--template", "--verbose",
+}
+
+var shellSeverities = []string{"LOW", "MEDIUM", "HIGH", "CRITICAL"}
+
+// handleShell drops the user into a REPL that reuses client's
+// authenticated connection and token across many queries, so repeated
+// exploration doesn't pay OAuth and TLS handshake latency on every
+// command the way separate CLI invocations do.
+func handleShell(ctx context.Context, client *sdk.Client) error {
+	historyFile, err := shellHistoryPath()
+	if err != nil {
+		return err
+	}
+
+	rl, err := readline.NewEx(&readline.Config{
+		Prompt:          "upwind> ",
+		HistoryFile:     historyFile,
+		AutoComplete:    newShellCompleter(ctx, client),
+		InterruptPrompt: "^C",
+		EOFPrompt:       "exit",
+	})
+	if err != nil {
+		return fmt.Errorf("starting shell: %w", err)
+	}
+	defer rl.Close()
+
+	fmt.Fprintln(rl.Stdout(), "Upwind interactive shell. Type a command (e.g. \"vulns --severity HIGH\"), \"\\pipe <cmd>\" to pipe the last result, or \"exit\".")
+
+	var lastResult []byte
+	for {
+		line, err := rl.Readline()
+		if err == readline.ErrInterrupt {
+			continue
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if line == "exit" || line == "quit" {
+			return nil
+		}
+		if line == "help" {
+			printUsage()
+			continue
+		}
+
+		if rest, ok := strings.CutPrefix(line, "\\pipe "); ok {
+			if lastResult == nil {
+				fmt.Fprintln(os.Stderr, "no previous result to pipe")
+				continue
+			}
+			if err := shellPipe(rest, lastResult); err != nil {
+				fmt.Fprintln(os.Stderr, "pipe error:", err)
+			}
+			continue
+		}
+
+		output, err := shellRunCommand(ctx, client, line)
+		if len(output) > 0 {
+			lastResult = output
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+		}
+	}
+}
+
+// shellHistoryPath returns ~/.upwind/history, creating its parent
+// directory if needed.
+func shellHistoryPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("resolving home directory: %w", err)
+	}
+	dir := filepath.Join(home, ".upwind")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("creating history directory: %w", err)
+	}
+	return filepath.Join(dir, "history"), nil
+}
+
+// shellRunCommand tokenizes line and dispatches it through
+// executeCommand exactly as a standalone CLI invocation would, swapping
+// os.Args for the duration of the call since getFlagValue/hasFlag read
+// it globally. It returns the command's raw stdout output, captured
+// alongside the copy printed to the terminal, so "\pipe" can forward it
+// to an external tool.
+func shellRunCommand(ctx context.Context, client *sdk.Client, line string) ([]byte, error) {
+	tokens := strings.Fields(line)
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+	command, rest := tokens[0], tokens[1:]
+
+	origArgs := os.Args
+	os.Args = append([]string{origArgs[0], command}, rest...)
+	defer func() { os.Args = origArgs }()
+
+	origStdout := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, fmt.Errorf("capturing output: %w", err)
+	}
+	os.Stdout = w
+
+	var buf bytes.Buffer
+	done := make(chan struct{})
+	go func() {
+		io.Copy(io.MultiWriter(origStdout, &buf), r)
+		close(done)
+	}()
+
+	cmdErr := executeCommand(ctx, client, command, rest)
+
+	w.Close()
+	os.Stdout = origStdout
+	<-done
+
+	return buf.Bytes(), cmdErr
+}
+
+// shellPipe runs shellCmd through the user's shell with data piped to
+// its stdin, e.g. "\pipe jq '.[] | .id'" against the last result's JSON.
+func shellPipe(shellCmd string, data []byte) error {
+	cmd := exec.Command("sh", "-c", shellCmd)
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// newShellCompleter builds tab completion over commands, common flags,
+// the severity enum, and (for --framework-id) framework IDs fetched
+// live from a short sample of configuration findings.
+func newShellCompleter(ctx context.Context, client *sdk.Client) readline.AutoCompleter {
+	items := make([]readline.PrefixCompleterInterface, 0, len(shellCommands)+len(shellFlags)+1)
+	for _, c := range shellCommands {
+		items = append(items, readline.PcItem(c))
+	}
+	for _, f := range shellFlags {
+		if f == "--severity" {
+			sev := make([]readline.PrefixCompleterInterface, len(shellSeverities))
+			for i, s := range shellSeverities {
+				sev[i] = readline.PcItem(s)
+			}
+			items = append(items, readline.PcItem(f, sev...))
+			continue
+		}
+		if f == "--framework-id" {
+			items = append(items, readline.PcItem(f, readline.PcItemDynamic(func(string) []string {
+				return shellFrameworkIDs(ctx, client)
+			})))
+			continue
+		}
+		items = append(items, readline.PcItem(f))
+	}
+	return readline.NewPrefixCompleter(items...)
+}
+
+// shellFrameworkIDs samples a few configuration findings to collect the
+// distinct compliance framework IDs currently in use, for --framework-id
+// completion. It's best-effort: any error or empty result just yields
+// no completions rather than interrupting the shell.
+func shellFrameworkIDs(parent context.Context, client *sdk.Client) []string {
+	ctx, cancel := context.WithTimeout(parent, 5*time.Second)
+	defer cancel()
+
+	findingsCh, errCh := client.ListConfigurationFindings(ctx, nil, &sdk.ConfigurationFindingsQuery{})
+
+	seen := make(map[string]struct{})
+	var ids []string
+	for finding := range findingsCh {
+		if finding.Framework == nil || finding.Framework.ID == "" {
+			continue
+		}
+		if _, ok := seen[finding.Framework.ID]; ok {
+			continue
+		}
+		seen[finding.Framework.ID] = struct{}{}
+		ids = append(ids, finding.Framework.ID)
+		if len(ids) >= 20 {
+			cancel()
+			break
+		}
+	}
+	<-errCh
+
+	return ids
+}
@@ -0,0 +1,63 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/tektite-io/upwind-go-sdk/webhookserver"
+)
+
+// secretFile is the shape of the --secret-file JSON given to
+// "webhooks serve".
+type secretFile struct {
+	Secret string `json:"secret"`
+}
+
+// handleWebhooksServe runs a webhookserver.Receiver that dumps every
+// verified delivery through the --output-selected OutputWriter,
+// reusing the same output machinery as every other command.
+func handleWebhooksServe(ctx context.Context, args []string) error {
+	addr := getFlagValue("--addr")
+	if addr == "" {
+		addr = ":8080"
+	}
+
+	secretFilePath := getFlagValue("--secret-file")
+	if secretFilePath == "" {
+		return fmt.Errorf("usage: webhooks serve --addr :8080 --secret-file secrets.json")
+	}
+
+	data, err := os.ReadFile(secretFilePath)
+	if err != nil {
+		return fmt.Errorf("reading secret file: %w", err)
+	}
+	var secrets secretFile
+	if err := json.Unmarshal(data, &secrets); err != nil {
+		return fmt.Errorf("parsing secret file: %w", err)
+	}
+	if secrets.Secret == "" {
+		return fmt.Errorf("secret file %s has no \"secret\" field", secretFilePath)
+	}
+
+	w, err := newOutputWriterFromFlags(os.Stdout)
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+
+	receiver := webhookserver.NewReceiver(nil, secrets.Secret, webhookserver.WithAddr(addr))
+	receiver.OnAny(func(ctx context.Context, delivery webhookserver.Delivery) {
+		if err := w.Write(delivery); err != nil {
+			fmt.Fprintln(os.Stderr, "Error writing delivery:", err)
+		}
+	})
+
+	fmt.Fprintf(os.Stderr, "Listening for webhook deliveries on %s%s\n", addr, webhookserver.DefaultPath)
+	return receiver.ListenAndServe(ctx)
+}
@@ -6,89 +6,149 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 )
 
-func (c *Client) GetVulnerabilityFindings(ctx context.Context, query FindingsQuery) ([]VulnerabilityFinding, error) {
-	var allResults []VulnerabilityFinding
-	baseURL := fmt.Sprintf("%s/organizations/%s/vulnerability-findings", c.baseURL, c.orgID)
+// Encode implements QueryEncoder, building the URL-encoded query string
+// for a single page of vulnerability findings.
+func (q VulnerabilityFindingsQuery) Encode(pageToken string) string {
+	params := url.Values{}
 
-	// Manually construct query parameters
-	var queryParams []string
-	if query.ImageName != nil {
-		queryParams = append(queryParams, "image-name="+*query.ImageName)
+	if pageToken != "" {
+		params.Add("page-token", pageToken)
+	} else if q.PageToken != "" {
+		params.Add("page-token", q.PageToken)
 	}
-	if query.InUse != nil {
-		queryParams = append(queryParams, "in-use="+fmt.Sprintf("%v", *query.InUse))
+
+	if q.CloudAccountID != "" {
+		params.Add("cloud-account-id", q.CloudAccountID)
+	}
+	if q.ClusterID != "" {
+		params.Add("cluster-id", q.ClusterID)
+	}
+	if q.Namespace != "" {
+		params.Add("namespace", q.Namespace)
+	}
+	if q.IngressActiveCommunication != nil {
+		params.Add("ingress-active-communication", fmt.Sprintf("%v", *q.IngressActiveCommunication))
+	}
+	if q.InternetExposure != nil {
+		params.Add("internet-exposure", fmt.Sprintf("%v", *q.InternetExposure))
+	}
+	if q.InUse != nil {
+		params.Add("in-use", fmt.Sprintf("%v", *q.InUse))
+	}
+	if q.Exploitable != nil {
+		params.Add("exploitable", fmt.Sprintf("%v", *q.Exploitable))
+	}
+	if q.FixAvailable != nil {
+		params.Add("fix-available", fmt.Sprintf("%v", *q.FixAvailable))
 	}
-	if query.IngressActiveCommunication != nil {
-		queryParams = append(queryParams, "ingress-active-communication="+fmt.Sprintf("%v", *query.IngressActiveCommunication))
+	if q.Severity != "" {
+		params.Add("severity", q.Severity)
 	}
-	if len(query.Severities) > 0 {
-		queryParams = append(queryParams, "severity="+strings.Join(query.Severities, ","))
+	if q.ImageName != "" {
+		params.Add("image-name", q.ImageName)
 	}
-	if query.PerPage != nil {
-		queryParams = append(queryParams, "per-page="+fmt.Sprintf("%d", *query.PerPage))
+	if q.Framework != "" {
+		params.Add("framework", q.Framework)
+	}
+	if q.PerPage > 0 {
+		params.Add("per-page", fmt.Sprintf("%d", q.PerPage))
 	}
 
-	queryString := strings.Join(queryParams, "&")
-	urlWithQuery := baseURL
+	return params.Encode()
+}
 
-	if len(queryParams) > 0 {
-		urlWithQuery += "?" + queryString
+// listVulnerabilityFindingsPage retrieves a single page of vulnerability findings.
+func (c *Client) listVulnerabilityFindingsPage(ctx context.Context, query VulnerabilityFindingsQuery, pageToken string, header *http.Header) ([]VulnerabilityFinding, string, error) {
+	urlPath := fmt.Sprintf("%s/organizations/%s/vulnerability-findings", c.config.GetBaseURL(), c.config.OrganizationID)
+	if queryParams := query.Encode(pageToken); queryParams != "" {
+		urlPath += "?" + queryParams
 	}
 
-	for {
-		req, err := http.NewRequestWithContext(ctx, "GET", urlWithQuery, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", urlPath, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("creating request: %w", err)
+	}
 
-		//log.Printf("GET %s", urlWithQuery)
-		//
-		//log.Printf("Req %w", req)
+	resp, err := c.doRequest(ctx, req)
+	if err != nil {
+		return nil, "", fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
 
-		if err != nil {
-			return nil, fmt.Errorf("creating request: %w", err)
-		}
+	if header != nil {
+		*header = resp.Header
+	}
 
-		resp, err := c.doRequest(ctx, req)
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, "", decodeError(resp, body)
+	}
 
-		//log.Printf("response: %+v\n", resp)
+	var results []VulnerabilityFinding
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, "", fmt.Errorf("decoding response: %w", err)
+	}
 
-		if err != nil {
-			return nil, fmt.Errorf("executing request: %w", err)
+	nextToken, err := extractNextLink(resp.Header.Get("Link"))
+	if err != nil {
+		return nil, "", fmt.Errorf("parsing pagination link: %w", err)
+	}
+	if nextToken != "" && strings.Contains(nextToken, "page-token=") {
+		if parsedURL, err := url.Parse(nextToken); err == nil {
+			nextToken = parsedURL.Query().Get("page-token")
 		}
+	}
 
-		defer func() {
-			if cerr := resp.Body.Close(); cerr != nil {
-				fmt.Printf("warning: closing response body: %v\n", cerr)
-			}
-		}()
+	return results, nextToken, nil
+}
 
-		if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
-			body, _ := io.ReadAll(resp.Body)
-			return nil, fmt.Errorf("API call failed: %s - %s", resp.Status, string(body))
-		}
+// ListVulnerabilityFindingsPaginator returns a Paginator over
+// vulnerability findings matching query, fetching pages on demand
+// instead of loading the full result set into memory.
+func (c *Client) ListVulnerabilityFindingsPaginator(query VulnerabilityFindingsQuery) *Paginator[VulnerabilityFinding] {
+	return NewPaginator(func(ctx context.Context, pageToken string, header *http.Header) ([]VulnerabilityFinding, string, error) {
+		return c.listVulnerabilityFindingsPage(ctx, query, pageToken, header)
+	})
+}
 
-		var results []VulnerabilityFinding
-		if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
-			return nil, fmt.Errorf("decoding response: %w", err)
-		}
+// GetVulnerabilityFindings retrieves all vulnerability findings matching
+// query, following pagination until exhausted. It is a thin
+// backward-compatible wrapper around ListVulnerabilityFindingsPaginator;
+// prefer that method directly for large result sets.
+func (c *Client) GetVulnerabilityFindings(ctx context.Context, query VulnerabilityFindingsQuery) ([]VulnerabilityFinding, error) {
+	return c.ListVulnerabilityFindingsPaginator(query).All(ctx)
+}
 
-		allResults = append(allResults, results...)
+// GetVulnerabilityFinding retrieves a specific vulnerability finding by
+// ID. A missing finding returns an error matching errors.Is(err,
+// ErrNotFound).
+func (c *Client) GetVulnerabilityFinding(ctx context.Context, findingID string) (*VulnerabilityFinding, error) {
+	urlPath := fmt.Sprintf("%s/organizations/%s/vulnerability-findings/%s", c.config.GetBaseURL(), c.config.OrganizationID, findingID)
 
-		// Check for next page
-		nextURL, err := extractNextLink(resp.Header.Get("Link"))
-		//
-		//log.Printf("Next Link: %+v\n", nextURL)
+	req, err := http.NewRequestWithContext(ctx, "GET", urlPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
 
-		if err != nil {
-			return nil, fmt.Errorf("extracting next link: %w", err)
-		}
-		if nextURL == "" {
-			break
-		}
+	resp, err := c.doRequest(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, decodeError(resp, body)
+	}
 
-		urlWithQuery = nextURL
+	var finding VulnerabilityFinding
+	if err := json.NewDecoder(resp.Body).Decode(&finding); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
 	}
 
-	return allResults, nil
+	return &finding, nil
 }
@@ -0,0 +1,66 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package sdk
+
+import (
+	"context"
+	"net/http"
+)
+
+// ApiEndpointsIterator walks API endpoints page by page without a
+// background goroutine or channel. Call Next until it returns false, then
+// check Err for any failure. Close releases resources held by the
+// iterator; it is safe to call multiple times. It is a thin wrapper
+// around ItemIterator.
+type ApiEndpointsIterator struct {
+	it *ItemIterator[ApiEndpoint]
+}
+
+// ListApiEndpointsIter returns an ApiEndpointsIterator over API
+// endpoints. Unlike ListApiEndpoints, it fetches pages synchronously on
+// demand and does not leak a goroutine if the caller stops iterating
+// early.
+func (c *Client) ListApiEndpointsIter(ctx context.Context, query *ApiEndpointsQuery) *ApiEndpointsIterator {
+	if query == nil {
+		query = &ApiEndpointsQuery{}
+	}
+	if query.PerPage == 0 {
+		query.PerPage = c.config.PageSize
+	}
+	return &ApiEndpointsIterator{
+		it: NewItemIterator(ctx, func(ctx context.Context, pageToken string, header *http.Header) ([]ApiEndpoint, string, error) {
+			return c.listApiEndpointsPage(ctx, query, pageToken, header)
+		}),
+	}
+}
+
+// Next advances the iterator, fetching the next page if necessary. It
+// returns false when iteration is complete or an error occurred; check
+// Err to distinguish the two.
+func (it *ApiEndpointsIterator) Next() bool {
+	return it.it.Next()
+}
+
+// Value returns the endpoint at the iterator's current position. It is
+// only valid after a call to Next that returned true.
+func (it *ApiEndpointsIterator) Value() ApiEndpoint {
+	return it.it.Value()
+}
+
+// Page returns the full page of endpoints the current Value was drawn from.
+func (it *ApiEndpointsIterator) Page() []ApiEndpoint {
+	return it.it.Page()
+}
+
+// Err returns the first error encountered during iteration, if any.
+func (it *ApiEndpointsIterator) Err() error {
+	return it.it.Err()
+}
+
+// Close marks the iterator as done, releasing its reference to the
+// current page.
+func (it *ApiEndpointsIterator) Close() error {
+	return it.it.Close()
+}
@@ -0,0 +1,58 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// This file is hand-maintained, not generated. api/openapi.yaml documents
+// these request bodies for reference, but real oapi-codegen output
+// doesn't match the SDK's existing hand-written types (e.g. it would
+// redeclare WorkflowConfig, already defined in types.go, with an
+// incompatible shape) - see api/openapi.yaml's header comment.
+
+package sdk
+
+// CreateWorkflowRequest is the request body for POST
+// /organizations/{organizationId}/workflows.
+type CreateWorkflowRequest struct {
+	Name   string          `json:"name"`
+	Type   string          `json:"type"`
+	Config *WorkflowConfig `json:"config,omitempty"`
+}
+
+// UpdateWorkflowRequest is the request body for PATCH
+// /organizations/{organizationId}/workflows/{workflowId}. Fields left
+// nil are omitted from the request and left unchanged server-side.
+type UpdateWorkflowRequest struct {
+	Name   *string         `json:"name,omitempty"`
+	Status *string         `json:"status,omitempty"`
+	Config *WorkflowConfig `json:"config,omitempty"`
+}
+
+// CreateIntegrationWebhookRequest is the request body for POST
+// /organizations/{organizationId}/integration-webhooks.
+type CreateIntegrationWebhookRequest struct {
+	Name   string                 `json:"name"`
+	Vendor string                 `json:"vendor"`
+	Config map[string]interface{} `json:"config,omitempty"`
+}
+
+// UpdateIntegrationWebhookRequest is the request body for PATCH
+// /organizations/{organizationId}/integration-webhooks/{webhookId}.
+// Fields left nil are omitted from the request and left unchanged
+// server-side.
+type UpdateIntegrationWebhookRequest struct {
+	Name   *string                `json:"name,omitempty"`
+	Status *string                `json:"status,omitempty"`
+	Config map[string]interface{} `json:"config,omitempty"`
+}
+
+// UpdateThreatDetectionRequest is the request body for PATCH
+// /organizations/{organizationId}/threat-detections/{detectionId}.
+type UpdateThreatDetectionRequest struct {
+	Status *string `json:"status,omitempty"`
+}
+
+// UpdateThreatPolicyRequest is the request body for PATCH
+// /organizations/{organizationId}/threat-policies/{policyId}.
+type UpdateThreatPolicyRequest struct {
+	Enabled *bool `json:"enabled,omitempty"`
+}
@@ -0,0 +1,129 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package sdk
+
+import "testing"
+
+func strPtr(s string) *string { return &s }
+func boolPtr(b bool) *bool    { return &b }
+
+func TestCreateWorkflowRequestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     CreateWorkflowRequest
+		wantErr bool
+	}{
+		{"valid", CreateWorkflowRequest{Name: "wf", Type: "remediation"}, false},
+		{"missing name", CreateWorkflowRequest{Type: "remediation"}, true},
+		{"missing type", CreateWorkflowRequest{Name: "wf"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUpdateWorkflowRequestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     UpdateWorkflowRequest
+		wantErr bool
+	}{
+		{"no status", UpdateWorkflowRequest{}, false},
+		{"valid status", UpdateWorkflowRequest{Status: strPtr(StatusEnabled)}, false},
+		{"invalid status", UpdateWorkflowRequest{Status: strPtr("BOGUS")}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCreateIntegrationWebhookRequestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     CreateIntegrationWebhookRequest
+		wantErr bool
+	}{
+		{"valid", CreateIntegrationWebhookRequest{Name: "hook", Vendor: "slack"}, false},
+		{"missing name", CreateIntegrationWebhookRequest{Vendor: "slack"}, true},
+		{"missing vendor", CreateIntegrationWebhookRequest{Name: "hook"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUpdateIntegrationWebhookRequestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     UpdateIntegrationWebhookRequest
+		wantErr bool
+	}{
+		{"no status", UpdateIntegrationWebhookRequest{}, false},
+		{"valid status", UpdateIntegrationWebhookRequest{Status: strPtr(StatusDisabled)}, false},
+		{"invalid status", UpdateIntegrationWebhookRequest{Status: strPtr("BOGUS")}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUpdateThreatDetectionRequestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     UpdateThreatDetectionRequest
+		wantErr bool
+	}{
+		{"no status", UpdateThreatDetectionRequest{}, false},
+		{"valid status", UpdateThreatDetectionRequest{Status: strPtr(StatusArchived)}, false},
+		{"invalid status", UpdateThreatDetectionRequest{Status: strPtr("BOGUS")}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUpdateThreatPolicyRequestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     UpdateThreatPolicyRequest
+		wantErr bool
+	}{
+		{"enabled set", UpdateThreatPolicyRequest{Enabled: boolPtr(true)}, false},
+		{"enabled unset", UpdateThreatPolicyRequest{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -7,16 +7,55 @@ package sdk
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"iter"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 )
 
+// ApiEndpoints returns a pull iterator over API endpoints matching
+// query, for use with a Go 1.23 range-over-func loop:
+//
+//	for ep, err := range client.ApiEndpoints(ctx, query) {
+//	    if err != nil {
+//	        log.Fatal(err)
+//	    }
+//	    process(ep)
+//	}
+//
+// Unlike ListApiEndpoints, breaking out of the loop early tears down the
+// underlying request cleanly instead of leaking a goroutine: the range
+// statement's implicit stop function runs when the loop exits, for any
+// reason.
+func (c *Client) ApiEndpoints(ctx context.Context, query *ApiEndpointsQuery) iter.Seq2[ApiEndpoint, error] {
+	return func(yield func(ApiEndpoint, error) bool) {
+		it := c.ListApiEndpointsIter(ctx, query)
+		defer it.Close()
+
+		for it.Next() {
+			if !yield(it.Value(), nil) {
+				return
+			}
+		}
+
+		if err := it.Err(); err != nil {
+			var zero ApiEndpoint
+			yield(zero, err)
+		}
+	}
+}
+
 // ListApiEndpoints streams API endpoints page by page via a channel.
 // This is memory-efficient for large datasets. The channel will be closed when done.
 // Returns an error channel that will receive any error that occurs during streaming.
+// It is implemented on top of ApiEndpoints; prefer that method directly
+// if you don't need the channel shape, since it avoids the background
+// goroutine. Set query.Prefetch to fetch pages ahead of the consumer
+// instead of one page at a time; see listApiEndpointsPrefetch.
 //
 // Example - streaming (memory efficient):
 //
@@ -33,6 +72,10 @@ import (
 //	endpointsCh, errCh := client.ListApiEndpoints(ctx, query)
 //	allEndpoints, err := sdk.CollectAll(ctx, endpointsCh, errCh)
 func (c *Client) ListApiEndpoints(ctx context.Context, query *ApiEndpointsQuery) (<-chan ApiEndpoint, <-chan error) {
+	if query != nil && query.Prefetch > 0 {
+		return c.listApiEndpointsPrefetch(ctx, query)
+	}
+
 	endpointsCh := make(chan ApiEndpoint, 100)
 	errCh := make(chan error, 1)
 
@@ -40,43 +83,220 @@ func (c *Client) ListApiEndpoints(ctx context.Context, query *ApiEndpointsQuery)
 		defer close(endpointsCh)
 		defer close(errCh)
 
-		if query == nil {
-			query = &ApiEndpointsQuery{}
-		}
+		for endpoint, err := range c.ApiEndpoints(ctx, query) {
+			if err != nil {
+				errCh <- err
+				return
+			}
 
-		if query.PerPage == 0 {
-			query.PerPage = c.config.PageSize
+			select {
+			case endpointsCh <- endpoint:
+			case <-ctx.Done():
+				errCh <- ctx.Err()
+				return
+			}
 		}
+	}()
 
-		pageToken := ""
-		for {
-			endpoints, nextToken, err := c.listApiEndpointsPage(ctx, query, pageToken)
+	return endpointsCh, errCh
+}
+
+// apiEndpointsPageResult is one fetched page in the prefetch pipeline,
+// tagged with the sequence number it was fetched in so the drain side
+// can re-order pages that complete out of order.
+type apiEndpointsPageResult struct {
+	seq   int
+	items []ApiEndpoint
+	err   error
+}
+
+// listApiEndpointsPrefetch streams API endpoints the same way
+// ListApiEndpoints does, but lets a dedicated fetch loop run up to
+// query.Prefetch pages ahead of the consumer, overlapping each page's
+// network round-trip with the time the caller spends draining the
+// previous one instead of blocking the next fetch until the caller asks
+// for more. query.Concurrency goroutines drain fetched pages off the
+// pipeline; this does not increase the number of in-flight HTTP
+// requests, since each page's token is only known once the previous
+// page has been fetched, but it keeps ordering correct if a future
+// per-item transform makes draining itself worth parallelizing. Pages
+// are always delivered to the caller in order, and the first error
+// (by page sequence, not by completion time) stops the stream.
+func (c *Client) listApiEndpointsPrefetch(ctx context.Context, query *ApiEndpointsQuery) (<-chan ApiEndpoint, <-chan error) {
+	endpointsCh := make(chan ApiEndpoint, 100)
+	errCh := make(chan error, 1)
+
+	concurrency := query.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	pagesCh := make(chan apiEndpointsPageResult, query.Prefetch)
+	completedCh := make(chan apiEndpointsPageResult, query.Prefetch)
+
+	// Fetch loop: walks the page-token chain, buffering up to
+	// query.Prefetch completed fetches in pagesCh so it can run ahead
+	// of however fast the drain workers below consume them.
+	go func() {
+		defer close(pagesCh)
+
+		paginator := c.ListApiEndpointsPaginator(query)
+		for seq := 0; paginator.HasNext(); seq++ {
+			items, err := paginator.Next(ctx)
+			select {
+			case pagesCh <- apiEndpointsPageResult{seq: seq, items: items, err: err}:
+			case <-ctx.Done():
+				return
+			}
 			if err != nil {
-				errCh <- err
 				return
 			}
+		}
+	}()
 
-			for _, endpoint := range endpoints {
+	// Drain workers pull completed pages off pagesCh and forward them
+	// for re-ordering. With concurrency == 1 (the default) this is a
+	// pass-through; higher values exist for future per-page work.
+	var wg sync.WaitGroup
+	wg.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			defer wg.Done()
+			for page := range pagesCh {
 				select {
-				case endpointsCh <- endpoint:
+				case completedCh <- page:
 				case <-ctx.Done():
-					errCh <- ctx.Err()
 					return
 				}
 			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(completedCh)
+	}()
+
+	// Re-order buffer: pages can complete out of sequence when
+	// concurrency > 1, so hold each one back until every
+	// earlier-numbered page has been delivered.
+	go func() {
+		defer cancel()
+		defer close(endpointsCh)
+		defer close(errCh)
+
+		pending := make(map[int]apiEndpointsPageResult)
+		next := 0
+
+		deliver := func(page apiEndpointsPageResult) bool {
+			if page.err != nil {
+				errCh <- page.err
+				return false
+			}
+			for _, item := range page.items {
+				select {
+				case endpointsCh <- item:
+				case <-ctx.Done():
+					errCh <- ctx.Err()
+					return false
+				}
+			}
+			return true
+		}
 
-			if nextToken == "" {
-				break
+		for page := range completedCh {
+			pending[page.seq] = page
+			for {
+				ready, ok := pending[next]
+				if !ok {
+					break
+				}
+				delete(pending, next)
+				next++
+				if !deliver(ready) {
+					return
+				}
 			}
-			pageToken = nextToken
 		}
 	}()
 
 	return endpointsCh, errCh
 }
 
-// listApiEndpointsPage retrieves a single page of API endpoints
-func (c *Client) listApiEndpointsPage(ctx context.Context, query *ApiEndpointsQuery, pageToken string) ([]ApiEndpoint, string, error) {
+// ListApiEndpointsWithCursor streams API endpoints the same way
+// ListApiEndpoints does, but each batch also carries the page token that
+// fetches the next page. Long-running exports can persist
+// CursoredBatch.NextPageToken after fully processing a batch and resume
+// from it on restart by setting query.PageToken, instead of starting
+// over from the beginning. Prefer ListApiEndpoints or CollectAll for the
+// simple, non-resumable case.
+func (c *Client) ListApiEndpointsWithCursor(ctx context.Context, query *ApiEndpointsQuery) (<-chan CursoredBatch[ApiEndpoint], <-chan error) {
+	batchCh := make(chan CursoredBatch[ApiEndpoint], 10)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(batchCh)
+		defer close(errCh)
+
+		paginator := c.ListApiEndpointsPaginator(query)
+		for paginator.HasNext() {
+			items, err := paginator.Next(ctx)
+			if err != nil {
+				errCh <- err
+				return
+			}
+
+			select {
+			case batchCh <- CursoredBatch[ApiEndpoint]{Items: items, NextPageToken: paginator.nextToken}:
+			case <-ctx.Done():
+				errCh <- ctx.Err()
+				return
+			}
+		}
+	}()
+
+	return batchCh, errCh
+}
+
+// ListApiEndpointsPaginator returns a Paginator over API endpoints
+// matching query, fetching pages on demand instead of loading the full
+// result set into memory.
+func (c *Client) ListApiEndpointsPaginator(query *ApiEndpointsQuery) *Paginator[ApiEndpoint] {
+	if query == nil {
+		query = &ApiEndpointsQuery{}
+	}
+	return NewPaginator(func(ctx context.Context, pageToken string, header *http.Header) ([]ApiEndpoint, string, error) {
+		return c.listApiEndpointsPage(ctx, query, pageToken, header)
+	})
+}
+
+// listApiEndpointsPage retrieves a single page of API endpoints,
+// retrying the whole page fetch (with a fresh per-page deadline each
+// time) if query.PageTimeout or a SetPageDeadline deadline expires
+// before it completes, so one slow page doesn't fail the entire stream.
+// Non-timeout errors are left to the transport's own retry middleware
+// and returned as-is.
+func (c *Client) listApiEndpointsPage(ctx context.Context, query *ApiEndpointsQuery, pageToken string, header *http.Header) ([]ApiEndpoint, string, error) {
+	for attempt := 0; ; attempt++ {
+		pageCtx, cancel := c.withPageTimeout(ctx, query.PageTimeout)
+		endpoints, nextToken, err := c.listApiEndpointsPageOnce(pageCtx, query, pageToken, header)
+		cancel()
+		if err == nil {
+			return endpoints, nextToken, nil
+		}
+		if !errors.Is(err, context.DeadlineExceeded) || attempt >= c.config.MaxRetries {
+			return nil, "", err
+		}
+		if !sleepOrBail(ctx, c.backoff(attempt, "")) {
+			return nil, "", ctx.Err()
+		}
+	}
+}
+
+// listApiEndpointsPageOnce makes a single attempt at fetching one page
+// of API endpoints, with no retry of its own.
+func (c *Client) listApiEndpointsPageOnce(ctx context.Context, query *ApiEndpointsQuery, pageToken string, header *http.Header) ([]ApiEndpoint, string, error) {
 	urlPath := fmt.Sprintf("%s/organizations/%s/apisecurity-endpoints", c.config.GetBaseURL(), c.config.OrganizationID)
 	queryParams := c.buildApiEndpointsQueryParams(query, pageToken)
 
@@ -95,9 +315,13 @@ func (c *Client) listApiEndpointsPage(ctx context.Context, query *ApiEndpointsQu
 	}
 	defer resp.Body.Close()
 
+	if header != nil {
+		*header = resp.Header
+	}
+
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, "", fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+		return nil, "", decodeError(resp, body)
 	}
 
 	var endpoints []ApiEndpoint
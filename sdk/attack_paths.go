@@ -0,0 +1,235 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// Attack path status values.
+const (
+	StatusActive = "active"
+	StatusFixed  = "fixed"
+)
+
+// Attack path processing status values, for attack paths whose graph is
+// computed asynchronously server-side.
+const (
+	ProcessingStatusProcessing = "PROCESSING"
+	ProcessingStatusDone       = "DONE"
+	ProcessingStatusFailed     = "FAILED"
+	ProcessingStatusTimeout    = "TIMEOUT"
+)
+
+// Risk factors an AttackPath can carry, mirroring the vocabulary used by
+// the Kubescape/Armo ecosystem.
+const (
+	RiskFactorExternalFacing = "EXTERNAL_FACING"
+	RiskFactorPrivileged     = "PRIVILEGED"
+	RiskFactorSecretAccess   = "SECRET_ACCESS"
+	RiskFactorDataAccess     = "DATA_ACCESS"
+	RiskFactorHostAccess     = "HOST_ACCESS"
+)
+
+// AttackPath models the chain of conditions an attacker could exploit to
+// reach impact, joining resources, vulnerability findings, and threat
+// detections into a single graph.
+type AttackPath struct {
+	ID               string               `json:"id"`
+	Status           string               `json:"status"`
+	ProcessingStatus string               `json:"processing_status,omitempty"`
+	Nodes            []AttackPathNode     `json:"nodes,omitempty"`
+	RiskFactors      []string             `json:"risk_factors,omitempty"`
+	MitreAttacks     []MitreAttackDetails `json:"mitre_attacks,omitempty"`
+}
+
+// AttackPathNode is a single step in an AttackPath: a resource, together
+// with whichever finding or detection put it on the path.
+type AttackPathNode struct {
+	Resource  *Resource             `json:"resource,omitempty"`
+	Finding   *VulnerabilityFinding `json:"finding,omitempty"`
+	Detection *ThreatDetection      `json:"detection,omitempty"`
+	Edges     []AttackPathEdge      `json:"edges,omitempty"`
+}
+
+// AttackPathEdge connects an AttackPathNode to another node later in the
+// same AttackPath.Nodes slice.
+type AttackPathEdge struct {
+	ToNodeIndex int    `json:"to_node_index"`
+	Relation    string `json:"relation,omitempty"`
+}
+
+// AttackPathsQuery represents query parameters for attack paths.
+type AttackPathsQuery struct {
+	Status         string
+	RiskFactor     string
+	CloudAccountID string
+	Framework      string
+	PerPage        int
+}
+
+// Encode implements QueryEncoder, building the URL-encoded query string
+// for a single page of attack paths.
+func (q AttackPathsQuery) Encode(pageToken string) string {
+	params := url.Values{}
+
+	if pageToken != "" {
+		params.Add("page-token", pageToken)
+	}
+	if q.Status != "" {
+		params.Add("status", q.Status)
+	}
+	if q.RiskFactor != "" {
+		params.Add("risk-factor", q.RiskFactor)
+	}
+	if q.CloudAccountID != "" {
+		params.Add("cloud-account-id", q.CloudAccountID)
+	}
+	if q.Framework != "" {
+		params.Add("framework", q.Framework)
+	}
+	if q.PerPage > 0 {
+		params.Add("per-page", fmt.Sprintf("%d", q.PerPage))
+	}
+
+	return params.Encode()
+}
+
+// listAttackPathsPage retrieves a single page of attack paths.
+func (c *Client) listAttackPathsPage(ctx context.Context, query AttackPathsQuery, pageToken string, header *http.Header) ([]AttackPath, string, error) {
+	urlPath := fmt.Sprintf("%s/organizations/%s/attack-paths", c.config.GetBaseURL(), c.config.OrganizationID)
+	if queryParams := query.Encode(pageToken); queryParams != "" {
+		urlPath += "?" + queryParams
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", urlPath, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("creating request: %w", err)
+	}
+
+	resp, err := c.doRequest(ctx, req)
+	if err != nil {
+		return nil, "", fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if header != nil {
+		*header = resp.Header
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, "", decodeError(resp, body)
+	}
+
+	var paths []AttackPath
+	if err := json.NewDecoder(resp.Body).Decode(&paths); err != nil {
+		return nil, "", fmt.Errorf("decoding response: %w", err)
+	}
+
+	nextToken, err := extractNextLink(resp.Header.Get("Link"))
+	if err != nil {
+		return nil, "", fmt.Errorf("parsing pagination link: %w", err)
+	}
+
+	for i := range paths {
+		enrichAttackPath(&paths[i])
+	}
+
+	return paths, nextToken, nil
+}
+
+// ListAttackPaths returns a Paginator over attack paths matching query.
+func (c *Client) ListAttackPaths(ctx context.Context, query AttackPathsQuery) *Paginator[AttackPath] {
+	return NewPaginator(func(ctx context.Context, pageToken string, header *http.Header) ([]AttackPath, string, error) {
+		return c.listAttackPathsPage(ctx, query, pageToken, header)
+	})
+}
+
+// GetAttackPath retrieves a single attack path by ID. A missing attack
+// path returns an error matching errors.Is(err, ErrNotFound).
+func (c *Client) GetAttackPath(ctx context.Context, id string) (*AttackPath, error) {
+	urlPath := fmt.Sprintf("%s/organizations/%s/attack-paths/%s", c.config.GetBaseURL(), c.config.OrganizationID, id)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", urlPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	resp, err := c.doRequest(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, decodeError(resp, body)
+	}
+
+	var path AttackPath
+	if err := json.NewDecoder(resp.Body).Decode(&path); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	enrichAttackPath(&path)
+
+	return &path, nil
+}
+
+// enrichAttackPath fills in any risk factors the server omitted, by
+// deriving them from each node's Resource. Factors already present in
+// path.RiskFactors are left untouched and never duplicated.
+func enrichAttackPath(path *AttackPath) {
+	have := make(map[string]bool, len(path.RiskFactors))
+	for _, rf := range path.RiskFactors {
+		have[rf] = true
+	}
+
+	for _, node := range path.Nodes {
+		for _, rf := range deriveRiskFactors(node) {
+			if !have[rf] {
+				have[rf] = true
+				path.RiskFactors = append(path.RiskFactors, rf)
+			}
+		}
+	}
+}
+
+// deriveRiskFactors computes the risk factors implied by a single
+// AttackPathNode's resource, for servers that don't compute them
+// server-side.
+func deriveRiskFactors(node AttackPathNode) []string {
+	if node.Resource == nil {
+		return nil
+	}
+
+	var factors []string
+	r := node.Resource
+
+	if r.InternetExposure != nil && r.InternetExposure.Ingress != nil && r.InternetExposure.Ingress.ActiveCommunication {
+		factors = append(factors, RiskFactorExternalFacing)
+	}
+
+	for _, category := range r.RiskCategories {
+		switch category {
+		case "PRIVILEGED":
+			factors = append(factors, RiskFactorPrivileged)
+		case "SECRET_ACCESS":
+			factors = append(factors, RiskFactorSecretAccess)
+		case "DATA_ACCESS":
+			factors = append(factors, RiskFactorDataAccess)
+		case "HOST_ACCESS":
+			factors = append(factors, RiskFactorHostAccess)
+		}
+	}
+
+	return factors
+}
@@ -0,0 +1,60 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package sdk
+
+import "testing"
+
+func TestEnrichAttackPathDerivesRiskFactors(t *testing.T) {
+	path := &AttackPath{
+		Status: StatusActive,
+		Nodes: []AttackPathNode{
+			{
+				Resource: &Resource{
+					InternetExposure: &InternetExposure{
+						Ingress: &InternetExposureDetails{ActiveCommunication: true},
+					},
+					RiskCategories: []string{"PRIVILEGED", "SECRET_ACCESS"},
+				},
+			},
+		},
+	}
+
+	enrichAttackPath(path)
+
+	want := map[string]bool{
+		RiskFactorExternalFacing: true,
+		RiskFactorPrivileged:     true,
+		RiskFactorSecretAccess:   true,
+	}
+	if len(path.RiskFactors) != len(want) {
+		t.Fatalf("RiskFactors = %v, want %d entries", path.RiskFactors, len(want))
+	}
+	for _, rf := range path.RiskFactors {
+		if !want[rf] {
+			t.Errorf("unexpected risk factor %q", rf)
+		}
+	}
+}
+
+func TestEnrichAttackPathDoesNotDuplicateExistingFactors(t *testing.T) {
+	path := &AttackPath{
+		RiskFactors: []string{RiskFactorExternalFacing},
+		Nodes: []AttackPathNode{
+			{
+				Resource: &Resource{
+					InternetExposure: &InternetExposure{
+						Ingress: &InternetExposureDetails{ActiveCommunication: true},
+					},
+				},
+			},
+		},
+	}
+
+	enrichAttackPath(path)
+
+	if len(path.RiskFactors) != 1 {
+		t.Errorf("RiskFactors = %v, want exactly 1 entry", path.RiskFactors)
+	}
+}
@@ -1,52 +1,256 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
 package sdk
 
 import (
 	"context"
 	"fmt"
+	"log"
 	"net/http"
+	"os"
 	"sync"
 	"time"
 
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/clientcredentials"
+	"golang.org/x/time/rate"
 )
 
+// HTTPClient is the interface used by Client to execute HTTP requests.
+// It is satisfied by *http.Client and allows callers to substitute their
+// own transport (e.g. for testing).
 type HTTPClient interface {
 	Do(req *http.Request) (*http.Response, error)
 }
 
+// Logger receives diagnostic messages from Client. The zero value of
+// Client uses NoOpLogger, so logging is silent unless EnableLogging or
+// SetLogger is called.
+type Logger interface {
+	Logf(format string, args ...interface{})
+}
+
+// NoOpLogger discards all log messages. It is the default Logger for
+// a newly created Client.
+type NoOpLogger struct{}
+
+// Logf implements Logger.
+func (NoOpLogger) Logf(format string, args ...interface{}) {}
+
+// stdLogger logs to the standard library's log package.
+type stdLogger struct {
+	*log.Logger
+}
+
+// Logf implements Logger.
+func (l *stdLogger) Logf(format string, args ...interface{}) {
+	l.Printf(format, args...)
+}
+
+// Client is an authenticated client for the Upwind API. A Client is safe
+// for concurrent use by multiple goroutines.
 type Client struct {
-	baseURL    string
+	config     *Config
 	httpClient HTTPClient
 	oauthCfg   *clientcredentials.Config
 	tokenSrc   oauth2.TokenSource
 	tokenMu    sync.Mutex
 	token      *oauth2.Token
-	retries    int
+	tokenCache TokenCache
+	logger     Logger
+	limiter    *rate.Limiter
+
+	closeRefresh chan struct{}
+	closeOnce    sync.Once
+
+	// middlewares are user-supplied and wrap the SDK's built-in auth,
+	// rate-limiting, and retry behavior. See WithMiddleware.
+	middlewares []Middleware
+	// transport is the fully composed request pipeline, rebuilt once in
+	// NewClient after all ClientOptions have been applied.
+	transport RoundTripFunc
+
+	// defaultContainer scopes API calls that are not given an explicit
+	// ResourceContainer. It is populated from config.OrganizationID
+	// unless overridden by WithDefaultContainer.
+	defaultContainer *ResourceContainer
+}
+
+// ClientOption customizes a Client constructed by NewClient.
+type ClientOption func(*Client)
+
+// WithHTTPClient overrides the HTTPClient used to execute requests.
+// Useful for tests and for injecting custom transports.
+func WithHTTPClient(httpClient HTTPClient) ClientOption {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
 }
 
-func NewClient(baseURL, tokenURL, clientID, clientSecret, scope string, retries int, httpClient HTTPClient) *Client {
-	cfg := &clientcredentials.Config{
-		ClientID:     clientID,
-		ClientSecret: clientSecret,
-		TokenURL:     tokenURL,
-		Scopes:       []string{scope},
+// WithDefaultContainer sets the ResourceContainer used by API methods
+// that are called with a nil container. By default this is
+// OrgResource(config.OrganizationID), which preserves the behavior of
+// a Client pinned to a single organization.
+func WithDefaultContainer(container *ResourceContainer) ClientOption {
+	return func(c *Client) {
+		c.defaultContainer = container
+	}
+}
+
+// WithTokenCache installs a TokenCache used to persist the OAuth2 token
+// between process invocations, overriding the FileTokenCache that
+// config.TokenCachePath would otherwise install.
+func WithTokenCache(cache TokenCache) ClientOption {
+	return func(c *Client) {
+		c.tokenCache = cache
+	}
+}
+
+// NewClient creates a new Upwind API client from the given configuration.
+func NewClient(config *Config, opts ...ClientOption) (*Client, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config is required")
+	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	oauthCfg := &clientcredentials.Config{
+		ClientID:     config.ClientID,
+		ClientSecret: config.ClientSecret,
+		TokenURL:     config.GetTokenURL(),
+		Scopes:       []string{config.GetAudience()},
 		AuthStyle:    oauth2.AuthStyleInParams,
 	}
 
-	if httpClient == nil {
-		httpClient = &http.Client{Timeout: 10 * time.Second}
+	c := &Client{
+		config:           config,
+		httpClient:       &http.Client{Timeout: 30 * time.Second},
+		oauthCfg:         oauthCfg,
+		tokenSrc:         oauthCfg.TokenSource(context.Background()),
+		logger:           NoOpLogger{},
+		limiter:          newRateLimiter(config.RateLimitPerSecond, config.RateLimitBurst),
+		defaultContainer: OrgResource(config.OrganizationID),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.tokenCache == nil && config.TokenCachePath != "" {
+		c.tokenCache = NewFileTokenCache(config.TokenCachePath)
+	}
+	if c.tokenCache != nil {
+		if cached, err := c.tokenCache.Load(); err == nil && cached != nil {
+			c.token = cached
+		}
+	}
+
+	c.transport = c.buildTransport()
+
+	if config.RefreshInBackground {
+		c.closeRefresh = make(chan struct{})
+		go c.refreshInBackground()
+	}
+
+	return c, nil
+}
+
+// Close stops the background token-refresh goroutine started by
+// config.RefreshInBackground. It is safe to call more than once, and a
+// no-op if background refresh was never enabled.
+func (c *Client) Close() error {
+	c.closeOnce.Do(func() {
+		if c.closeRefresh != nil {
+			close(c.closeRefresh)
+		}
+	})
+	return nil
+}
+
+// refreshInBackground periodically checks the cached token's remaining
+// lifetime and proactively refreshes it before the 60s cutoff getToken
+// itself enforces, so an API call right after a refresh never blocks on
+// a synchronous token exchange.
+func (c *Client) refreshInBackground() {
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.tokenMu.Lock()
+			needsRefresh := c.token == nil || time.Until(c.token.Expiry) <= 60*time.Second
+			c.tokenMu.Unlock()
+			if needsRefresh {
+				_, _ = c.getToken(context.Background())
+			}
+		case <-c.closeRefresh:
+			return
+		}
+	}
+}
+
+// NewClientFromEnv creates a new Client using configuration loaded from
+// environment variables. See LoadConfigFromEnv for the supported variables.
+func NewClientFromEnv(opts ...ClientOption) (*Client, error) {
+	config, err := LoadConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(config, opts...)
+}
+
+// NewClientFromFile creates a new Client using configuration loaded from
+// a JSON file. See LoadConfigFromFile.
+func NewClientFromFile(path string, opts ...ClientOption) (*Client, error) {
+	config, err := LoadConfigFromFile(path)
+	if err != nil {
+		return nil, err
 	}
+	return NewClient(config, opts...)
+}
 
-	tokenSrc := cfg.TokenSource(context.Background())
+// GetOrganizationID returns the organization ID this client was configured with.
+func (c *Client) GetOrganizationID() string {
+	return c.config.OrganizationID
+}
+
+// EnableLogging turns on logging to stderr for this client. Use SetLogger
+// instead if you need to direct log output elsewhere.
+func (c *Client) EnableLogging() {
+	c.logger = &stdLogger{log.New(os.Stderr, "[upwind-sdk] ", log.LstdFlags)}
+}
 
-	return &Client{
-		baseURL:    baseURL,
-		httpClient: httpClient,
-		oauthCfg:   cfg,
-		tokenSrc:   tokenSrc,
-		retries:    retries,
+// SetLogger installs a custom Logger. Pass NoOpLogger{} to silence logging.
+func (c *Client) SetLogger(logger Logger) {
+	if logger == nil {
+		logger = NoOpLogger{}
 	}
+	c.logger = logger
+}
+
+// container resolves the ResourceContainer to use for a request, falling
+// back to the client's default container when none is given explicitly.
+func (c *Client) container(container *ResourceContainer) *ResourceContainer {
+	if container != nil {
+		return container
+	}
+	return c.defaultContainer
+}
+
+// newRateLimiter builds the token-bucket limiter for a Client.
+// requestsPerSecond <= 0 means "no limit".
+func newRateLimiter(requestsPerSecond, burst int) *rate.Limiter {
+	if requestsPerSecond <= 0 {
+		return rate.NewLimiter(rate.Inf, 0)
+	}
+	if burst <= 0 {
+		burst = requestsPerSecond
+	}
+	return rate.NewLimiter(rate.Limit(requestsPerSecond), burst)
 }
 
 func (c *Client) getToken(ctx context.Context) (*oauth2.Token, error) {
@@ -63,40 +267,53 @@ func (c *Client) getToken(ctx context.Context) (*oauth2.Token, error) {
 	}
 
 	c.token = token
+	if c.tokenCache != nil {
+		if err := c.tokenCache.Save(token); err != nil {
+			c.logger.Logf("failed to persist token cache: %v", err)
+		}
+	}
 	return token, nil
 }
 
+// doRequest executes req through the client's middleware chain: user
+// middlewares registered via WithMiddleware, wrapping the built-in auth,
+// rate-limiting, and retry behavior (see buildTransport).
 func (c *Client) doRequest(ctx context.Context, req *http.Request) (*http.Response, error) {
-	var lastErr error
-	for i := 0; i <= c.retries; i++ {
-		token, err := c.getToken(ctx)
-
-		//log.Printf("token: %+v\n", token)
-
-		if err != nil {
-			return nil, fmt.Errorf("token error: %w", err)
-		}
+	return c.transport(req.Clone(ctx))
+}
 
-		req = req.Clone(ctx)
-		req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+// pageDeadlineKey is the context key SetPageDeadline stores its
+// deadline under.
+type pageDeadlineKey struct{}
 
-		resp, err := c.httpClient.Do(req)
-		if err != nil {
-			lastErr = err
-			time.Sleep(time.Duration(i+1) * 100 * time.Millisecond)
-			continue
-		}
+// SetPageDeadline returns a context carrying a per-page HTTP deadline
+// for the SDK's cursored list endpoints (e.g. ListApiEndpoints),
+// independent of a query's PageTimeout field and of ctx's own deadline.
+// Pass the result to a List call to apply t to each page fetch made
+// with it; advanced callers can call this again between pages to
+// tighten or loosen the deadline without reconstructing their query.
+func (c *Client) SetPageDeadline(ctx context.Context, t time.Time) context.Context {
+	return context.WithValue(ctx, pageDeadlineKey{}, t)
+}
 
-		if resp.StatusCode == http.StatusUnauthorized && i < c.retries {
-			c.tokenMu.Lock()
-			c.token = nil
-			c.tokenMu.Unlock()
-			_ = resp.Body.Close()
-			continue
-		}
+// pageDeadlineFrom reports the deadline set by SetPageDeadline on ctx,
+// if any.
+func pageDeadlineFrom(ctx context.Context) (time.Time, bool) {
+	t, ok := ctx.Value(pageDeadlineKey{}).(time.Time)
+	return t, ok
+}
 
-		return resp, nil
+// withPageTimeout returns a child of ctx bounded by, in order of
+// precedence, a deadline set via SetPageDeadline or timeout, so a
+// single slow page can't stall an otherwise-healthy stream forever
+// while ctx's own deadline still bounds the whole operation. If neither
+// applies, ctx is returned unchanged alongside a no-op cancel.
+func (c *Client) withPageTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if deadline, ok := pageDeadlineFrom(ctx); ok {
+		return context.WithDeadline(ctx, deadline)
 	}
-
-	return nil, fmt.Errorf("all retries failed: %w", lastErr)
+	if timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+	return ctx, func() {}
 }
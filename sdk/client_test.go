@@ -5,7 +5,14 @@
 package sdk
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
 	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
 )
 
 func TestNewClient(t *testing.T) {
@@ -58,6 +65,68 @@ func TestNewClient(t *testing.T) {
 	}
 }
 
+func TestClientLoadsCachedToken(t *testing.T) {
+	cache := NewFileTokenCache(filepath.Join(t.TempDir(), "token.json"))
+	want := &oauth2.Token{AccessToken: "cached-token", Expiry: time.Now().Add(time.Hour)}
+	if err := cache.Save(want); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	client, err := NewClient(&Config{
+		ClientID:       "test-client",
+		ClientSecret:   "test-secret",
+		OrganizationID: "test-org",
+		Region:         RegionUS,
+		MaxConcurrency: 10,
+		PageSize:       100,
+	}, WithTokenCache(cache))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	token, err := client.getToken(context.Background())
+	if err != nil {
+		t.Fatalf("getToken() error = %v", err)
+	}
+	if token.AccessToken != want.AccessToken {
+		t.Errorf("getToken() = %+v, want the cached token to be reused without a network round trip", token)
+	}
+}
+
+func TestClientCloseStopsBackgroundRefresh(t *testing.T) {
+	client, err := NewClient(&Config{
+		ClientID:            "test-client",
+		ClientSecret:        "test-secret",
+		OrganizationID:      "test-org",
+		Region:              RegionUS,
+		MaxConcurrency:      10,
+		PageSize:            100,
+		RefreshInBackground: true,
+	})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		if err := client.Close(); err != nil {
+			t.Errorf("Close() error = %v", err)
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Close() did not return; background refresh goroutine may not have stopped")
+	}
+
+	// Close must be idempotent.
+	if err := client.Close(); err != nil {
+		t.Errorf("second Close() error = %v", err)
+	}
+}
+
 func TestClientLogging(t *testing.T) {
 	cfg := &Config{
 		ClientID:       "test-client",
@@ -81,3 +150,256 @@ func TestClientLogging(t *testing.T) {
 	customLogger := &NoOpLogger{}
 	client.SetLogger(customLogger)
 }
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		wantOk bool
+	}{
+		{"seconds form", "5", 5 * time.Second, true},
+		{"zero seconds", "0", 0, true},
+		{"negative seconds", "-1", 0, false},
+		{"invalid value", "not-a-date", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.value)
+			if ok != tt.wantOk {
+				t.Fatalf("parseRetryAfter() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if ok && got != tt.want {
+				t.Errorf("parseRetryAfter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientBackoffRespectsMaxBackoff(t *testing.T) {
+	cfg := &Config{
+		ClientID:       "test-client",
+		ClientSecret:   "test-secret",
+		OrganizationID: "test-org",
+		Region:         RegionUS,
+		MaxRetries:     3,
+		MaxConcurrency: 10,
+		PageSize:       100,
+		MaxBackoff:     2 * time.Second,
+	}
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	if d := client.backoff(10, ""); d > cfg.MaxBackoff {
+		t.Errorf("backoff() = %v, want <= %v", d, cfg.MaxBackoff)
+	}
+
+	if d := client.backoff(0, "3600"); d != cfg.MaxBackoff {
+		t.Errorf("backoff() with Retry-After = %v, want capped at %v", d, cfg.MaxBackoff)
+	}
+}
+
+func TestClientBackoffNoJitter(t *testing.T) {
+	disabled := false
+	cfg := &Config{
+		ClientID:       "test-client",
+		ClientSecret:   "test-secret",
+		OrganizationID: "test-org",
+		Region:         RegionUS,
+		MaxRetries:     3,
+		MaxConcurrency: 10,
+		PageSize:       100,
+		BaseBackoff:    50 * time.Millisecond,
+		Jitter:         &disabled,
+	}
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	if d := client.backoff(1, ""); d != 100*time.Millisecond {
+		t.Errorf("backoff() with jitter disabled = %v, want exactly 100ms", d)
+	}
+}
+
+func TestClientBackoffRespectsMaxRetryAfter(t *testing.T) {
+	cfg := &Config{
+		ClientID:       "test-client",
+		ClientSecret:   "test-secret",
+		OrganizationID: "test-org",
+		Region:         RegionUS,
+		MaxRetries:     3,
+		MaxConcurrency: 10,
+		PageSize:       100,
+		MaxBackoff:     30 * time.Second,
+		MaxRetryAfter:  2 * time.Second,
+	}
+
+	client := newTestClient(t, cfg)
+
+	if d := client.backoff(0, "3600"); d != cfg.MaxRetryAfter {
+		t.Errorf("backoff() with Retry-After = %v, want capped at MaxRetryAfter %v", d, cfg.MaxRetryAfter)
+	}
+}
+
+func TestClientBackoffIgnoresRetryAfterWhenDisabled(t *testing.T) {
+	disabled := false
+	cfg := &Config{
+		ClientID:          "test-client",
+		ClientSecret:      "test-secret",
+		OrganizationID:    "test-org",
+		Region:            RegionUS,
+		MaxRetries:        3,
+		MaxConcurrency:    10,
+		PageSize:          100,
+		MaxBackoff:        2 * time.Second,
+		RespectRetryAfter: &disabled,
+	}
+
+	client := newTestClient(t, cfg)
+
+	if d := client.backoff(0, "3600"); d == 3600*time.Second {
+		t.Errorf("backoff() with RespectRetryAfter disabled = %v, want computed backoff, not the Retry-After value", d)
+	}
+}
+
+func newTestClient(t *testing.T, cfg *Config) *Client {
+	t.Helper()
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	return client
+}
+
+func fakeResponse(status int) *http.Response {
+	rec := httptest.NewRecorder()
+	rec.Code = status
+	rec.Body.WriteString("{}")
+	return rec.Result()
+}
+
+func TestRetryMiddlewareSkipsNonIdempotentByDefault(t *testing.T) {
+	client := newTestClient(t, &Config{
+		ClientID:       "test-client",
+		ClientSecret:   "test-secret",
+		OrganizationID: "test-org",
+		Region:         RegionUS,
+		MaxRetries:     3,
+		MaxConcurrency: 10,
+		PageSize:       100,
+	})
+
+	attempts := 0
+	next := RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		return fakeResponse(http.StatusServiceUnavailable), nil
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "https://api.upwind.io/v1/workflows", nil)
+	resp, err := client.retryMiddleware()(next)(req)
+	if err != nil {
+		t.Fatalf("retryMiddleware() error = %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1 (no retry for POST)", attempts)
+	}
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("StatusCode = %d, want %d", resp.StatusCode, http.StatusServiceUnavailable)
+	}
+}
+
+func TestRetryMiddlewareRetriesUnauthorizedForNonIdempotent(t *testing.T) {
+	client := newTestClient(t, &Config{
+		ClientID:       "test-client",
+		ClientSecret:   "test-secret",
+		OrganizationID: "test-org",
+		Region:         RegionUS,
+		MaxRetries:     3,
+		MaxConcurrency: 10,
+		PageSize:       100,
+		BaseBackoff:    time.Millisecond,
+	})
+
+	attempts := 0
+	next := RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		if attempts == 1 {
+			return fakeResponse(http.StatusUnauthorized), nil
+		}
+		return fakeResponse(http.StatusOK), nil
+	})
+
+	req := httptest.NewRequest(http.MethodPatch, "https://api.upwind.io/v1/workflows/wf_1", nil)
+	resp, err := client.retryMiddleware()(next)(req)
+	if err != nil {
+		t.Fatalf("retryMiddleware() error = %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("attempts = %d, want 2 (401 is always retried once, even for PATCH)", attempts)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("StatusCode = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+}
+
+func TestRetryMiddlewareRetriesNonIdempotentWhenOptedIn(t *testing.T) {
+	client := newTestClient(t, &Config{
+		ClientID:           "test-client",
+		ClientSecret:       "test-secret",
+		OrganizationID:     "test-org",
+		Region:             RegionUS,
+		MaxRetries:         3,
+		MaxConcurrency:     10,
+		PageSize:           100,
+		BaseBackoff:        time.Millisecond,
+		RetryNonIdempotent: true,
+	})
+
+	attempts := 0
+	next := RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		if attempts == 1 {
+			return fakeResponse(http.StatusServiceUnavailable), nil
+		}
+		return fakeResponse(http.StatusOK), nil
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "https://api.upwind.io/v1/workflows", nil)
+	resp, err := client.retryMiddleware()(next)(req)
+	if err != nil {
+		t.Fatalf("retryMiddleware() error = %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("attempts = %d, want 2 when RetryNonIdempotent is set", attempts)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("StatusCode = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+}
+
+func TestIsRetryableStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		statuses []int
+		want     bool
+	}{
+		{"match", 429, []int{429, 503}, true},
+		{"no match", 500, []int{429, 503}, false},
+		{"empty list", 429, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRetryableStatus(tt.status, tt.statuses); got != tt.want {
+				t.Errorf("isRetryableStatus() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -7,8 +7,10 @@ package sdk
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
 	"strings"
+	"time"
 )
 
 // Region represents the Upwind API region
@@ -45,16 +47,79 @@ type Config struct {
 	PageSize int `json:"page_size"`
 	// RateLimitPerSecond is the maximum number of requests per second (0 = no limit)
 	RateLimitPerSecond int `json:"rate_limit_per_second"`
+	// RateLimitBurst is the maximum number of requests allowed to burst
+	// above RateLimitPerSecond before the token bucket throttles callers.
+	RateLimitBurst int `json:"rate_limit_burst"`
+	// BaseBackoff is the delay before the first retry when computing
+	// exponential backoff (doubled on each subsequent attempt, before
+	// jitter and the MaxBackoff cap are applied).
+	BaseBackoff time.Duration `json:"base_backoff"`
+	// MaxBackoff is the upper bound on the delay between retries, whether
+	// derived from a Retry-After header or computed via exponential backoff.
+	MaxBackoff time.Duration `json:"max_backoff"`
+	// MaxRetryAfter caps a Retry-After-derived delay independently of
+	// MaxBackoff, e.g. to protect against an adversarial or
+	// misconfigured header demanding an hour-long wait. If zero,
+	// Retry-After delays fall back to the MaxBackoff cap.
+	MaxRetryAfter time.Duration `json:"max_retry_after,omitempty"`
+	// RespectRetryAfter controls whether the retry middleware honors a
+	// server-provided Retry-After header in preference to computed
+	// exponential backoff. Defaults to true; set to false to always use
+	// exponential backoff with jitter instead.
+	RespectRetryAfter *bool `json:"respect_retry_after,omitempty"`
+	// RetryableStatuses overrides the set of HTTP status codes the retry
+	// middleware treats as transient (in addition to transport errors and
+	// 401, which is always retried once to pick up a refreshed token). If
+	// empty, it defaults to 429, 502, 503, and 504.
+	RetryableStatuses []int `json:"retryable_statuses,omitempty"`
+	// Jitter enables full jitter on the computed exponential backoff
+	// delay, so concurrent clients retrying after the same failure don't
+	// reconverge on the same schedule. Retry-After-derived delays are
+	// never jittered. Defaults to true.
+	Jitter *bool `json:"jitter,omitempty"`
+	// RetryNonIdempotent opts non-idempotent verbs (POST, PATCH) into the
+	// same retry behavior as GET/PUT/DELETE. By default the retry
+	// middleware never retries them, since a request that failed after
+	// reaching the server may have already taken effect.
+	RetryNonIdempotent bool `json:"retry_non_idempotent,omitempty"`
+	// GHSABaseURL is the base URL used to fetch GitHub Security
+	// Advisories for vulnerability enrichment (optional, defaults to
+	// https://api.github.com).
+	GHSABaseURL string `json:"ghsa_base_url,omitempty"`
+	// GHSAToken is an optional GitHub token sent as a Bearer credential
+	// on GHSA requests, raising GitHub's unauthenticated rate limit.
+	GHSAToken string `json:"ghsa_token,omitempty"`
+	// TokenCachePath, if set, persists the OAuth2 token as JSON at this
+	// path between process invocations via a FileTokenCache, so a CLI
+	// doesn't re-authenticate with the token endpoint on every run.
+	// Ignored if a Client is constructed with WithTokenCache.
+	TokenCachePath string `json:"token_cache_path,omitempty"`
+	// RefreshInBackground starts a goroutine that proactively refreshes
+	// the OAuth2 token shortly before it expires, so the first API call
+	// after startup doesn't pay the latency of a synchronous token
+	// refresh.
+	RefreshInBackground bool `json:"refresh_in_background,omitempty"`
+	// Profile is the name of the profile this Config was resolved from,
+	// when loaded via a ProfileFile. It has no effect on API behavior;
+	// it's informational, e.g. for a CLI to print which profile is active.
+	Profile string `json:"profile,omitempty"`
 }
 
 // DefaultConfig returns a Config with default values
 func DefaultConfig() *Config {
+	jitter := true
+	respectRetryAfter := true
 	return &Config{
 		Region:             RegionUS,
 		MaxRetries:         3,
 		MaxConcurrency:     10,
 		PageSize:           100,
 		RateLimitPerSecond: 10,
+		RateLimitBurst:     10,
+		BaseBackoff:        100 * time.Millisecond,
+		MaxBackoff:         30 * time.Second,
+		Jitter:             &jitter,
+		RespectRetryAfter:  &respectRetryAfter,
 	}
 }
 
@@ -70,8 +135,27 @@ func DefaultConfig() *Config {
 //   - UPWIND_MAX_CONCURRENCY: Maximum concurrent requests (default: 10)
 //   - UPWIND_PAGE_SIZE: Default page size (default: 100)
 //   - UPWIND_RATE_LIMIT: Requests per second limit (default: 10)
+//   - UPWIND_RATE_LIMIT_BURST: Token bucket burst size (default: 10)
+//   - UPWIND_MAX_BACKOFF: Maximum retry backoff, e.g. "30s" (default: 30s)
+//   - UPWIND_MAX_RETRY_AFTER: Maximum Retry-After delay to honor, e.g. "60s" (default: falls back to UPWIND_MAX_BACKOFF)
+//   - UPWIND_GHSA_BASE_URL: GitHub Security Advisories API base URL (default: https://api.github.com)
+//   - UPWIND_GHSA_TOKEN: GitHub token for GHSA requests (optional)
+//   - UPWIND_TOKEN_CACHE: Path to persist the OAuth2 token between runs (optional)
+//   - UPWIND_PROFILE: Name of the profile to select from a ProfileFile (optional)
 func LoadConfigFromEnv() (*Config, error) {
 	cfg := DefaultConfig()
+	mergeConfig(cfg, envOverlay())
+	return cfg, cfg.Validate()
+}
+
+// envOverlay reads the environment variables documented on
+// LoadConfigFromEnv into a Config whose unset fields are left at their
+// zero value, so it can be merged onto another Config (via mergeConfig)
+// without clobbering fields the environment doesn't mention. Used both
+// by LoadConfigFromEnv directly and by EnvConfigSource as a
+// ConfigLoader layer.
+func envOverlay() *Config {
+	cfg := &Config{}
 
 	if clientID := os.Getenv("UPWIND_CLIENT_ID"); clientID != "" {
 		cfg.ClientID = clientID
@@ -126,7 +210,42 @@ func LoadConfigFromEnv() (*Config, error) {
 		}
 	}
 
-	return cfg, cfg.Validate()
+	if rateLimitBurst := os.Getenv("UPWIND_RATE_LIMIT_BURST"); rateLimitBurst != "" {
+		var burst int
+		if _, err := fmt.Sscanf(rateLimitBurst, "%d", &burst); err == nil {
+			cfg.RateLimitBurst = burst
+		}
+	}
+
+	if maxBackoff := os.Getenv("UPWIND_MAX_BACKOFF"); maxBackoff != "" {
+		if d, err := time.ParseDuration(maxBackoff); err == nil {
+			cfg.MaxBackoff = d
+		}
+	}
+
+	if maxRetryAfter := os.Getenv("UPWIND_MAX_RETRY_AFTER"); maxRetryAfter != "" {
+		if d, err := time.ParseDuration(maxRetryAfter); err == nil {
+			cfg.MaxRetryAfter = d
+		}
+	}
+
+	if ghsaBaseURL := os.Getenv("UPWIND_GHSA_BASE_URL"); ghsaBaseURL != "" {
+		cfg.GHSABaseURL = ghsaBaseURL
+	}
+
+	if ghsaToken := os.Getenv("UPWIND_GHSA_TOKEN"); ghsaToken != "" {
+		cfg.GHSAToken = ghsaToken
+	}
+
+	if tokenCache := os.Getenv("UPWIND_TOKEN_CACHE"); tokenCache != "" {
+		cfg.TokenCachePath = tokenCache
+	}
+
+	if profile := os.Getenv("UPWIND_PROFILE"); profile != "" {
+		cfg.Profile = profile
+	}
+
+	return cfg
 }
 
 // LoadConfigFromFile loads configuration from a JSON file
@@ -172,6 +291,32 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("page_size must be between 1 and 10000")
 	}
 
+	if c.RateLimitPerSecond < 0 {
+		return fmt.Errorf("rate_limit_per_second must be >= 0")
+	}
+
+	if c.RateLimitBurst < 0 {
+		return fmt.Errorf("rate_limit_burst must be >= 0")
+	}
+
+	if c.BaseBackoff < 0 {
+		return fmt.Errorf("base_backoff must be >= 0")
+	}
+
+	if c.MaxBackoff < 0 {
+		return fmt.Errorf("max_backoff must be >= 0")
+	}
+
+	if c.MaxRetryAfter < 0 {
+		return fmt.Errorf("max_retry_after must be >= 0")
+	}
+
+	for _, status := range c.RetryableStatuses {
+		if status < 100 || status > 599 {
+			return fmt.Errorf("retryable_statuses contains invalid HTTP status: %d", status)
+		}
+	}
+
 	return nil
 }
 
@@ -199,6 +344,64 @@ func (c *Config) GetTokenURL() string {
 	return "https://auth.upwind.io/oauth/token"
 }
 
+// GetGHSABaseURL returns the base URL for GitHub Security Advisories
+// requests.
+func (c *Config) GetGHSABaseURL() string {
+	if c.GHSABaseURL != "" {
+		return c.GHSABaseURL
+	}
+	return "https://api.github.com"
+}
+
+// GetBaseBackoff returns the delay before the first retry attempt,
+// defaulting to 100ms if unset.
+func (c *Config) GetBaseBackoff() time.Duration {
+	if c.BaseBackoff > 0 {
+		return c.BaseBackoff
+	}
+	return 100 * time.Millisecond
+}
+
+// GetRetryableStatuses returns the HTTP status codes the retry
+// middleware treats as transient, defaulting to 429, 502, 503, and 504.
+// This is also what backs each page fetch in the SDK's list endpoints:
+// since every page is fetched through doRequest, a transient failure on
+// any one page retries independently instead of failing the whole
+// stream.
+func (c *Config) GetRetryableStatuses() []int {
+	if len(c.RetryableStatuses) > 0 {
+		return c.RetryableStatuses
+	}
+	return []int{
+		http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout,
+	}
+}
+
+// JitterEnabled reports whether the retry middleware should apply full
+// jitter to its computed exponential backoff delay. Defaults to true.
+func (c *Config) JitterEnabled() bool {
+	return c.Jitter == nil || *c.Jitter
+}
+
+// RespectRetryAfterEnabled reports whether the retry middleware should
+// honor a server-provided Retry-After header in preference to computed
+// exponential backoff. Defaults to true.
+func (c *Config) RespectRetryAfterEnabled() bool {
+	return c.RespectRetryAfter == nil || *c.RespectRetryAfter
+}
+
+// GetMaxRetryAfter returns the cap applied to a Retry-After-derived
+// delay, falling back to MaxBackoff when MaxRetryAfter is unset.
+func (c *Config) GetMaxRetryAfter() time.Duration {
+	if c.MaxRetryAfter > 0 {
+		return c.MaxRetryAfter
+	}
+	return c.MaxBackoff
+}
+
 // GetAudience returns the OAuth2 audience based on the region
 func (c *Config) GetAudience() string {
 	switch c.Region {
@@ -0,0 +1,188 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package sdk
+
+import (
+	"errors"
+	"os"
+)
+
+// ConfigSource produces a Config overlay for a ConfigLoader layer. A nil
+// *Config with a nil error means "nothing to layer", e.g. an optional
+// file that doesn't exist; fields left at their zero value don't
+// override fields set by earlier layers.
+type ConfigSource func() (*Config, error)
+
+// ConfigLoader resolves a Config by layering sources in increasing
+// precedence order: each Layer call's non-zero fields override the
+// fields set by earlier layers. The documented chain for a CLI or other
+// multi-profile consumer is:
+//
+//	DefaultConfig < file defaults < selected file profile < environment < flags
+//
+// i.e. construct the loader, then Layer ProfileFileSource, then
+// EnvConfigSource, then a flag-derived ConfigSource, in that order.
+type ConfigLoader struct {
+	cfg *Config
+}
+
+// NewConfigLoader starts a ConfigLoader from DefaultConfig.
+func NewConfigLoader() *ConfigLoader {
+	return &ConfigLoader{cfg: DefaultConfig()}
+}
+
+// Layer runs source and merges its overlay's non-zero fields on top of
+// the loader's current Config. Later Layer calls take precedence over
+// earlier ones.
+func (l *ConfigLoader) Layer(source ConfigSource) error {
+	overlay, err := source()
+	if err != nil {
+		return err
+	}
+	if overlay != nil {
+		mergeConfig(l.cfg, overlay)
+	}
+	return nil
+}
+
+// Resolve validates and returns the fully layered Config.
+func (l *ConfigLoader) Resolve() (*Config, error) {
+	if err := l.cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return l.cfg, nil
+}
+
+// EnvConfigSource returns a ConfigSource overlaying only the
+// environment variables that are actually set (see LoadConfigFromEnv
+// for the full list), leaving every other field at its zero value.
+func EnvConfigSource() ConfigSource {
+	return func() (*Config, error) {
+		return envOverlay(), nil
+	}
+}
+
+// ProfileFileSource returns a ConfigSource that loads the ProfileFile
+// at path (DefaultProfilePath if empty) and resolves profileName
+// against it (pf.Current if profileName is empty). A missing file is
+// treated as "nothing to layer" rather than an error, so a CLI can
+// layer this source unconditionally before a user has ever run `config
+// init`. An empty profileName with no pf.Current configured overlays
+// only pf's embedded defaults, without selecting a named profile.
+func ProfileFileSource(path, profileName string) ConfigSource {
+	return func() (*Config, error) {
+		if path == "" {
+			var err error
+			path, err = DefaultProfilePath()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		pf, err := LoadProfileFile(path)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		name := profileName
+		if name == "" {
+			name = pf.Current
+		}
+		if name == "" {
+			cfg := pf.Config
+			return &cfg, nil
+		}
+		return pf.Profile(name)
+	}
+}
+
+// StaticConfigSource returns a ConfigSource that always overlays cfg
+// unchanged, for layering in values already resolved elsewhere (e.g.
+// CLI flags).
+func StaticConfigSource(cfg *Config) ConfigSource {
+	return func() (*Config, error) {
+		return cfg, nil
+	}
+}
+
+// mergeConfig copies overlay's non-zero-valued fields onto dst in
+// place. A zero-valued overlay field (the empty string, 0, a nil
+// pointer, or an empty slice) is treated as "not set" and left alone,
+// so overlay doesn't have to be a complete Config - only the fields a
+// given layer actually wants to override.
+func mergeConfig(dst, overlay *Config) {
+	if overlay.ClientID != "" {
+		dst.ClientID = overlay.ClientID
+	}
+	if overlay.ClientSecret != "" {
+		dst.ClientSecret = overlay.ClientSecret
+	}
+	if overlay.OrganizationID != "" {
+		dst.OrganizationID = overlay.OrganizationID
+	}
+	if overlay.Region != "" {
+		dst.Region = overlay.Region
+	}
+	if overlay.BaseURL != "" {
+		dst.BaseURL = overlay.BaseURL
+	}
+	if overlay.TokenURL != "" {
+		dst.TokenURL = overlay.TokenURL
+	}
+	if overlay.MaxRetries != 0 {
+		dst.MaxRetries = overlay.MaxRetries
+	}
+	if overlay.MaxConcurrency != 0 {
+		dst.MaxConcurrency = overlay.MaxConcurrency
+	}
+	if overlay.PageSize != 0 {
+		dst.PageSize = overlay.PageSize
+	}
+	if overlay.RateLimitPerSecond != 0 {
+		dst.RateLimitPerSecond = overlay.RateLimitPerSecond
+	}
+	if overlay.RateLimitBurst != 0 {
+		dst.RateLimitBurst = overlay.RateLimitBurst
+	}
+	if overlay.BaseBackoff != 0 {
+		dst.BaseBackoff = overlay.BaseBackoff
+	}
+	if overlay.MaxBackoff != 0 {
+		dst.MaxBackoff = overlay.MaxBackoff
+	}
+	if overlay.MaxRetryAfter != 0 {
+		dst.MaxRetryAfter = overlay.MaxRetryAfter
+	}
+	if len(overlay.RetryableStatuses) > 0 {
+		dst.RetryableStatuses = overlay.RetryableStatuses
+	}
+	if overlay.Jitter != nil {
+		dst.Jitter = overlay.Jitter
+	}
+	if overlay.RespectRetryAfter != nil {
+		dst.RespectRetryAfter = overlay.RespectRetryAfter
+	}
+	if overlay.RetryNonIdempotent {
+		dst.RetryNonIdempotent = true
+	}
+	if overlay.GHSABaseURL != "" {
+		dst.GHSABaseURL = overlay.GHSABaseURL
+	}
+	if overlay.GHSAToken != "" {
+		dst.GHSAToken = overlay.GHSAToken
+	}
+	if overlay.TokenCachePath != "" {
+		dst.TokenCachePath = overlay.TokenCachePath
+	}
+	if overlay.RefreshInBackground {
+		dst.RefreshInBackground = true
+	}
+	if overlay.Profile != "" {
+		dst.Profile = overlay.Profile
+	}
+}
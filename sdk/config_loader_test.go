@@ -0,0 +1,157 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package sdk
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestMergeConfigOverlaysOnlyNonZeroFields(t *testing.T) {
+	dst := &Config{
+		ClientID:   "original-id",
+		MaxRetries: 3,
+		Region:     RegionUS,
+	}
+	overlay := &Config{
+		ClientID: "overlay-id",
+		Region:   RegionEU,
+	}
+
+	mergeConfig(dst, overlay)
+
+	if dst.ClientID != "overlay-id" {
+		t.Errorf("ClientID = %q, want overlay value", dst.ClientID)
+	}
+	if dst.Region != RegionEU {
+		t.Errorf("Region = %q, want overlay value", dst.Region)
+	}
+	if dst.MaxRetries != 3 {
+		t.Errorf("MaxRetries = %d, want dst value preserved (overlay left it zero)", dst.MaxRetries)
+	}
+}
+
+func TestMergeConfigBoolAndPointerFields(t *testing.T) {
+	jitter := false
+	dst := DefaultConfig()
+	overlay := &Config{Jitter: &jitter, RetryNonIdempotent: true}
+
+	mergeConfig(dst, overlay)
+
+	if dst.JitterEnabled() {
+		t.Error("JitterEnabled() = true, want false after overlay")
+	}
+	if !dst.RetryNonIdempotent {
+		t.Error("RetryNonIdempotent = false, want true after overlay")
+	}
+}
+
+func TestConfigLoaderLayersInOrder(t *testing.T) {
+	loader := NewConfigLoader()
+
+	if err := loader.Layer(StaticConfigSource(&Config{ClientID: "from-first-layer", ClientSecret: "secret"})); err != nil {
+		t.Fatalf("Layer() error = %v", err)
+	}
+	if err := loader.Layer(StaticConfigSource(&Config{ClientID: "from-second-layer"})); err != nil {
+		t.Fatalf("Layer() error = %v", err)
+	}
+	if err := loader.Layer(StaticConfigSource(&Config{OrganizationID: "org-1"})); err != nil {
+		t.Fatalf("Layer() error = %v", err)
+	}
+
+	cfg, err := loader.Resolve()
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if cfg.ClientID != "from-second-layer" {
+		t.Errorf("ClientID = %q, want the later layer to win", cfg.ClientID)
+	}
+	if cfg.ClientSecret != "secret" {
+		t.Errorf("ClientSecret = %q, want it preserved from the first layer", cfg.ClientSecret)
+	}
+	if cfg.OrganizationID != "org-1" {
+		t.Errorf("OrganizationID = %q, want org-1", cfg.OrganizationID)
+	}
+}
+
+func TestConfigLoaderResolveValidates(t *testing.T) {
+	loader := NewConfigLoader()
+	if _, err := loader.Resolve(); err == nil {
+		t.Error("Resolve() error = nil, want an error for missing required fields")
+	}
+}
+
+func TestProfileFileSourceMissingFileIsNoop(t *testing.T) {
+	source := ProfileFileSource(filepath.Join(t.TempDir(), "missing.yaml"), "")
+	overlay, err := source()
+	if err != nil {
+		t.Fatalf("ProfileFileSource() error = %v, want nil for a missing file", err)
+	}
+	if overlay != nil {
+		t.Errorf("overlay = %+v, want nil for a missing file", overlay)
+	}
+}
+
+func TestProfileFileSourceResolvesNamedProfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	pf := &ProfileFile{
+		Config:  Config{Region: RegionUS},
+		Current: "dev",
+		Profiles: map[string]*Config{
+			"dev":  {ClientID: "dev-id", ClientSecret: "dev-secret", OrganizationID: "org"},
+			"prod": {ClientID: "prod-id", ClientSecret: "prod-secret", OrganizationID: "org"},
+		},
+	}
+	if err := pf.WriteFile(path); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	source := ProfileFileSource(path, "prod")
+	overlay, err := source()
+	if err != nil {
+		t.Fatalf("ProfileFileSource() error = %v", err)
+	}
+	if overlay.ClientID != "prod-id" {
+		t.Errorf("ClientID = %q, want prod-id", overlay.ClientID)
+	}
+	if overlay.Profile != "prod" {
+		t.Errorf("Profile = %q, want prod", overlay.Profile)
+	}
+
+	source = ProfileFileSource(path, "")
+	overlay, err = source()
+	if err != nil {
+		t.Fatalf("ProfileFileSource() error = %v", err)
+	}
+	if overlay.ClientID != "dev-id" {
+		t.Errorf("ClientID = %q, want dev-id (pf.Current)", overlay.ClientID)
+	}
+}
+
+func TestDefaultProfilePathUnderHomeDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	path, err := DefaultProfilePath()
+	if err != nil {
+		t.Fatalf("DefaultProfilePath() error = %v", err)
+	}
+	want := filepath.Join(home, ".upwind", "config.yaml")
+	if path != want {
+		t.Errorf("DefaultProfilePath() = %q, want %q", path, want)
+	}
+}
+
+func TestEnvConfigSourceReadsProfileVar(t *testing.T) {
+	t.Setenv("UPWIND_PROFILE", "staging")
+
+	overlay, err := EnvConfigSource()()
+	if err != nil {
+		t.Fatalf("EnvConfigSource() error = %v", err)
+	}
+	if overlay.Profile != "staging" {
+		t.Errorf("Profile = %q, want staging", overlay.Profile)
+	}
+}
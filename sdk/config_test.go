@@ -7,6 +7,7 @@ package sdk
 import (
 	"os"
 	"testing"
+	"time"
 )
 
 func TestDefaultConfig(t *testing.T) {
@@ -27,6 +28,108 @@ func TestDefaultConfig(t *testing.T) {
 	if cfg.RateLimitPerSecond != 10 {
 		t.Errorf("Expected default rate limit 10, got %d", cfg.RateLimitPerSecond)
 	}
+	if cfg.RateLimitBurst != 10 {
+		t.Errorf("Expected default rate limit burst 10, got %d", cfg.RateLimitBurst)
+	}
+	if cfg.BaseBackoff != 100*time.Millisecond {
+		t.Errorf("Expected default base backoff 100ms, got %s", cfg.BaseBackoff)
+	}
+	if cfg.MaxBackoff != 30*time.Second {
+		t.Errorf("Expected default max backoff 30s, got %s", cfg.MaxBackoff)
+	}
+	if !cfg.JitterEnabled() {
+		t.Error("Expected jitter enabled by default")
+	}
+}
+
+func TestConfigRetryDefaults(t *testing.T) {
+	cfg := &Config{}
+
+	if got := cfg.GetBaseBackoff(); got != 100*time.Millisecond {
+		t.Errorf("GetBaseBackoff() = %v, want 100ms", got)
+	}
+	if !cfg.JitterEnabled() {
+		t.Error("JitterEnabled() = false, want true when unset")
+	}
+
+	statuses := cfg.GetRetryableStatuses()
+	if len(statuses) != 4 || statuses[0] != 429 || statuses[1] != 502 || statuses[2] != 503 || statuses[3] != 504 {
+		t.Errorf("GetRetryableStatuses() = %v, want [429 502 503 504]", statuses)
+	}
+
+	disabled := false
+	cfg.Jitter = &disabled
+	if cfg.JitterEnabled() {
+		t.Error("JitterEnabled() = true, want false when explicitly disabled")
+	}
+
+	cfg.RetryableStatuses = []int{500}
+	if got := cfg.GetRetryableStatuses(); len(got) != 1 || got[0] != 500 {
+		t.Errorf("GetRetryableStatuses() = %v, want [500]", got)
+	}
+}
+
+func TestConfigRespectRetryAfter(t *testing.T) {
+	cfg := &Config{}
+
+	if !cfg.RespectRetryAfterEnabled() {
+		t.Error("RespectRetryAfterEnabled() = false, want true when unset")
+	}
+
+	disabled := false
+	cfg.RespectRetryAfter = &disabled
+	if cfg.RespectRetryAfterEnabled() {
+		t.Error("RespectRetryAfterEnabled() = true, want false when explicitly disabled")
+	}
+
+	if got := cfg.GetMaxRetryAfter(); got != 0 {
+		t.Errorf("GetMaxRetryAfter() = %v, want 0 when both MaxRetryAfter and MaxBackoff are unset", got)
+	}
+
+	cfg.MaxBackoff = 30 * time.Second
+	if got := cfg.GetMaxRetryAfter(); got != 30*time.Second {
+		t.Errorf("GetMaxRetryAfter() = %v, want to fall back to MaxBackoff", got)
+	}
+
+	cfg.MaxRetryAfter = 10 * time.Second
+	if got := cfg.GetMaxRetryAfter(); got != 10*time.Second {
+		t.Errorf("GetMaxRetryAfter() = %v, want MaxRetryAfter to take precedence", got)
+	}
+}
+
+func TestConfigValidationRetryFields(t *testing.T) {
+	base := func() *Config {
+		return &Config{
+			ClientID:       "test-client",
+			ClientSecret:   "test-secret",
+			OrganizationID: "test-org",
+			Region:         RegionUS,
+			MaxConcurrency: 10,
+			PageSize:       100,
+		}
+	}
+
+	if err := base().Validate(); err != nil {
+		t.Fatalf("Validate() error = %v, want nil", err)
+	}
+
+	negBase := base()
+	negBase.BaseBackoff = -time.Second
+	if err := negBase.Validate(); err == nil {
+		t.Error("Validate() error = nil, want error for negative base_backoff")
+	}
+
+	badStatus := base()
+	badStatus.RetryableStatuses = []int{999}
+	if err := badStatus.Validate(); err == nil {
+		t.Error("Validate() error = nil, want error for out-of-range retryable status")
+	}
+
+	negRetryAfter := base()
+	negRetryAfter.MaxRetryAfter = -time.Second
+	if err := negRetryAfter.Validate(); err == nil {
+		t.Error("Validate() error = nil, want error for negative max_retry_after")
+	}
 }
 
 func TestConfigValidation(t *testing.T) {
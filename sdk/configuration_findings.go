@@ -17,10 +17,13 @@ import (
 // ListConfigurationFindings streams configuration findings page by page via a channel.
 // This is memory-efficient for large datasets. The channel will be closed when done.
 // Returns an error channel that will receive any error that occurs during streaming.
+// It is implemented on top of ListConfigurationFindingsIter; prefer that
+// method directly if you don't need the channel shape, since it avoids
+// the background goroutine.
 //
 // Example - streaming (memory efficient):
 //
-//	findings, errCh := client.ListConfigurationFindings(ctx, query)
+//	findings, errCh := client.ListConfigurationFindings(ctx, nil, query)
 //	for finding := range findings {
 //	    process(finding)
 //	}
@@ -30,9 +33,12 @@ import (
 //
 // Example - collect all (loads everything in memory):
 //
-//	findingsCh, errCh := client.ListConfigurationFindings(ctx, query)
+//	findingsCh, errCh := client.ListConfigurationFindings(ctx, sdk.OrgResource("org-abc"), query)
 //	allFindings, err := sdk.CollectAll(ctx, findingsCh, errCh)
-func (c *Client) ListConfigurationFindings(ctx context.Context, query *ConfigurationFindingsQuery) (<-chan ConfigurationFinding, <-chan error) {
+//
+// container scopes the request; pass nil to use the client's default
+// container (see WithDefaultContainer).
+func (c *Client) ListConfigurationFindings(ctx context.Context, container *ResourceContainer, query *ConfigurationFindingsQuery) (<-chan ConfigurationFinding, <-chan error) {
 	findingsCh := make(chan ConfigurationFinding, 100)
 	errCh := make(chan error, 1)
 
@@ -40,40 +46,43 @@ func (c *Client) ListConfigurationFindings(ctx context.Context, query *Configura
 		defer close(findingsCh)
 		defer close(errCh)
 
-		if query == nil {
-			query = &ConfigurationFindingsQuery{}
-		}
+		it := c.ListConfigurationFindingsIter(ctx, container, query)
+		defer it.Close()
 
-		pageToken := ""
-		for {
-			findings, nextToken, err := c.listConfigurationFindingsPage(ctx, query, pageToken)
-			if err != nil {
-				errCh <- err
+		for it.Next() {
+			select {
+			case findingsCh <- it.Value():
+			case <-ctx.Done():
+				errCh <- ctx.Err()
 				return
 			}
+		}
 
-			for _, finding := range findings {
-				select {
-				case findingsCh <- finding:
-				case <-ctx.Done():
-					errCh <- ctx.Err()
-					return
-				}
-			}
-
-			if nextToken == "" {
-				break
-			}
-			pageToken = nextToken
+		if err := it.Err(); err != nil {
+			errCh <- err
 		}
 	}()
 
 	return findingsCh, errCh
 }
 
+// ListConfigurationFindingsPaginator returns a Paginator over
+// configuration findings, scoped by container (nil uses the client's
+// default container), fetching pages on demand instead of loading the
+// full result set into memory.
+func (c *Client) ListConfigurationFindingsPaginator(container *ResourceContainer, query *ConfigurationFindingsQuery) *Paginator[ConfigurationFinding] {
+	if query == nil {
+		query = &ConfigurationFindingsQuery{}
+	}
+	container = c.container(container)
+	return NewPaginator(func(ctx context.Context, pageToken string, header *http.Header) ([]ConfigurationFinding, string, error) {
+		return c.listConfigurationFindingsPage(ctx, container, query, pageToken, header)
+	})
+}
+
 // listConfigurationFindingsPage retrieves a single page of configuration findings
-func (c *Client) listConfigurationFindingsPage(ctx context.Context, query *ConfigurationFindingsQuery, pageToken string) ([]ConfigurationFinding, string, error) {
-	urlPath := fmt.Sprintf("%s/organizations/%s/configuration-findings", c.config.GetBaseURL(), c.config.OrganizationID)
+func (c *Client) listConfigurationFindingsPage(ctx context.Context, container *ResourceContainer, query *ConfigurationFindingsQuery, pageToken string, header *http.Header) ([]ConfigurationFinding, string, error) {
+	urlPath := fmt.Sprintf("%s/%s/configuration-findings", c.config.GetBaseURL(), container.URLFragment())
 	queryParams := c.buildConfigurationFindingsQueryParams(query, pageToken)
 
 	if len(queryParams) > 0 {
@@ -91,9 +100,13 @@ func (c *Client) listConfigurationFindingsPage(ctx context.Context, query *Confi
 	}
 	defer resp.Body.Close()
 
+	if header != nil {
+		*header = resp.Header
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, "", fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+		return nil, "", decodeError(resp, body)
 	}
 
 	var findings []ConfigurationFinding
@@ -118,10 +131,15 @@ func (c *Client) listConfigurationFindingsPage(ctx context.Context, query *Confi
 	return findings, nextToken, nil
 }
 
-// GetConfigurationFinding retrieves a specific configuration finding by ID
-func (c *Client) GetConfigurationFinding(ctx context.Context, findingID string, includeCloudAccountTags bool) (*ConfigurationFinding, error) {
-	urlPath := fmt.Sprintf("%s/organizations/%s/configuration-findings/%s",
-		c.config.GetBaseURL(), c.config.OrganizationID, findingID)
+// GetConfigurationFinding retrieves a specific configuration finding by
+// ID. A missing finding returns an error matching errors.Is(err,
+// ErrNotFound).
+// container scopes the request; pass nil to use the client's default
+// container (see WithDefaultContainer).
+func (c *Client) GetConfigurationFinding(ctx context.Context, container *ResourceContainer, findingID string, includeCloudAccountTags bool) (*ConfigurationFinding, error) {
+	container = c.container(container)
+	urlPath := fmt.Sprintf("%s/%s/configuration-findings/%s",
+		c.config.GetBaseURL(), container.URLFragment(), findingID)
 
 	if includeCloudAccountTags {
 		urlPath += "?include-cloud-account-tags=true"
@@ -138,13 +156,9 @@ func (c *Client) GetConfigurationFinding(ctx context.Context, findingID string,
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("configuration finding not found: %s", findingID)
-	}
-
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+		return nil, decodeError(resp, body)
 	}
 
 	var finding ConfigurationFinding
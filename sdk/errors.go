@@ -0,0 +1,174 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"time"
+)
+
+// APIError represents a non-2xx response from the Upwind API. Callers
+// that need to branch on the kind of failure should use errors.Is
+// against ErrNotFound, ErrUnauthorized, or ErrRateLimited rather than
+// comparing StatusCode directly, since that also matches errors wrapped
+// by the retry loop.
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	RequestID  string
+	RetryAfter time.Duration
+	Body       []byte
+	// Problem holds the RFC 7807 Problem Details payload when the
+	// response's Content-Type is application/problem+json. Use
+	// errors.As(err, &problem) to retrieve it; nil for the legacy JSON
+	// error envelope.
+	Problem *ProblemError
+}
+
+// Error implements error.
+func (e *APIError) Error() string {
+	if e.Problem != nil {
+		return e.Problem.Error()
+	}
+	if e.Message != "" {
+		return fmt.Sprintf("API error %d: %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("API error %d: %s", e.StatusCode, string(e.Body))
+}
+
+// Unwrap exposes Problem so errors.As(err, &problem) finds it through an
+// *APIError without callers needing to know APIError carries it.
+func (e *APIError) Unwrap() error {
+	if e.Problem == nil {
+		return nil
+	}
+	return e.Problem
+}
+
+// Is reports whether target is an *APIError with the same StatusCode,
+// so errors.Is(err, ErrNotFound) works regardless of the Message, Code,
+// or Body carried by err.
+func (e *APIError) Is(target error) bool {
+	t, ok := target.(*APIError)
+	if !ok {
+		return false
+	}
+	return e.StatusCode == t.StatusCode
+}
+
+// Sentinel APIErrors for use with errors.Is. Only StatusCode is
+// compared, so these match any APIError with the corresponding status.
+var (
+	ErrNotFound     = &APIError{StatusCode: http.StatusNotFound}
+	ErrUnauthorized = &APIError{StatusCode: http.StatusUnauthorized}
+	ErrRateLimited  = &APIError{StatusCode: http.StatusTooManyRequests}
+)
+
+// errorEnvelope is the JSON shape the Upwind API uses to describe
+// errors in the response body.
+type errorEnvelope struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+	RequestID string `json:"request_id"`
+}
+
+// ProblemError is an RFC 7807 (application/problem+json) Problem
+// Details object. The Upwind API returns these from some endpoints
+// instead of the legacy errorEnvelope shape; decodeError detects the
+// Content-Type and populates APIError.Problem accordingly. Fields beyond
+// the five standard members are collected in Extensions.
+type ProblemError struct {
+	Type       string                 `json:"type,omitempty"`
+	Title      string                 `json:"title,omitempty"`
+	Status     int                    `json:"status,omitempty"`
+	Detail     string                 `json:"detail,omitempty"`
+	Instance   string                 `json:"instance,omitempty"`
+	Extensions map[string]interface{} `json:"-"`
+}
+
+// Error implements error.
+func (p *ProblemError) Error() string {
+	if p.Detail != "" {
+		return fmt.Sprintf("%s: %s", p.Title, p.Detail)
+	}
+	return p.Title
+}
+
+// UnmarshalJSON implements json.Unmarshaler, splitting the five standard
+// Problem Details members from any additional extension members into
+// Extensions.
+func (p *ProblemError) UnmarshalJSON(data []byte) error {
+	type standard ProblemError
+	var s standard
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	*p = ProblemError(s)
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	for _, key := range []string{"type", "title", "status", "detail", "instance"} {
+		delete(raw, key)
+	}
+	if len(raw) > 0 {
+		p.Extensions = raw
+	}
+	return nil
+}
+
+// decodeError builds an *APIError from a non-2xx http.Response. It reads
+// and consumes resp.Body; the caller is still responsible for closing
+// it. A Content-Type of application/problem+json is parsed as an RFC
+// 7807 ProblemError; otherwise the server's JSON error envelope is
+// parsed on a best-effort basis, and a body that matches neither shape
+// still yields an APIError with Body populated and Code/Message left
+// empty.
+func decodeError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Body:       body,
+		RequestID:  resp.Header.Get("X-Request-Id"),
+	}
+
+	if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+		apiErr.RetryAfter = retryAfter
+	}
+
+	if mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type")); err == nil && mediaType == "application/problem+json" {
+		var problem ProblemError
+		if err := json.Unmarshal(body, &problem); err == nil {
+			apiErr.Problem = &problem
+			apiErr.Message = problem.Detail
+			if apiErr.Message == "" {
+				apiErr.Message = problem.Title
+			}
+			if apiErr.RequestID == "" {
+				if reqID, ok := problem.Extensions["request_id"].(string); ok {
+					apiErr.RequestID = reqID
+				}
+			}
+			return apiErr
+		}
+	}
+
+	var envelope errorEnvelope
+	if err := json.Unmarshal(body, &envelope); err == nil {
+		apiErr.Code = envelope.Error.Code
+		apiErr.Message = envelope.Error.Message
+		if envelope.RequestID != "" {
+			apiErr.RequestID = envelope.RequestID
+		}
+	}
+
+	return apiErr
+}
@@ -0,0 +1,129 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package sdk
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDecodeError(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		header     http.Header
+		body       string
+		wantCode   string
+		wantRetry  bool
+		is         error
+	}{
+		{
+			name:       "not found with envelope",
+			statusCode: http.StatusNotFound,
+			body:       `{"error":{"code":"not_found","message":"finding not found"},"request_id":"req-1"}`,
+			wantCode:   "not_found",
+			is:         ErrNotFound,
+		},
+		{
+			name:       "rate limited with retry-after seconds",
+			statusCode: http.StatusTooManyRequests,
+			header:     http.Header{"Retry-After": []string{"2"}},
+			body:       `{"error":{"code":"rate_limited"}}`,
+			wantCode:   "rate_limited",
+			wantRetry:  true,
+			is:         ErrRateLimited,
+		},
+		{
+			name:       "unauthorized with non-JSON body",
+			statusCode: http.StatusUnauthorized,
+			body:       "token expired",
+			is:         ErrUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			rec.Code = tt.statusCode
+			for k, vs := range tt.header {
+				for _, v := range vs {
+					rec.Header().Add(k, v)
+				}
+			}
+
+			apiErr := decodeError(rec.Result(), []byte(tt.body))
+
+			if apiErr.StatusCode != tt.statusCode {
+				t.Errorf("StatusCode = %d, want %d", apiErr.StatusCode, tt.statusCode)
+			}
+			if apiErr.Code != tt.wantCode {
+				t.Errorf("Code = %q, want %q", apiErr.Code, tt.wantCode)
+			}
+			if tt.wantRetry && apiErr.RetryAfter == 0 {
+				t.Error("RetryAfter = 0, want non-zero")
+			}
+			if !errors.Is(apiErr, tt.is) {
+				t.Errorf("errors.Is(apiErr, %v) = false, want true", tt.is)
+			}
+			if apiErr.Error() == "" {
+				t.Error("Error() returned empty string")
+			}
+		})
+	}
+}
+
+func TestDecodeErrorProblemDetails(t *testing.T) {
+	rec := httptest.NewRecorder()
+	rec.Code = http.StatusConflict
+	rec.Header().Set("Content-Type", "application/problem+json")
+	body := `{
+		"type": "https://upwind.io/problems/stale-revision",
+		"title": "Stale revision",
+		"status": 409,
+		"detail": "the workflow was modified since it was last read",
+		"instance": "/workflows/wf-1",
+		"request_id": "req-42"
+	}`
+
+	apiErr := decodeError(rec.Result(), []byte(body))
+
+	if apiErr.Problem == nil {
+		t.Fatal("Problem = nil, want populated ProblemError")
+	}
+	if apiErr.Problem.Title != "Stale revision" || apiErr.Problem.Status != 409 {
+		t.Errorf("Problem = %+v", apiErr.Problem)
+	}
+	if apiErr.Problem.Extensions["request_id"] != "req-42" {
+		t.Errorf("Extensions = %v, want request_id preserved", apiErr.Problem.Extensions)
+	}
+	if apiErr.RequestID != "req-42" {
+		t.Errorf("RequestID = %q, want extension request_id to populate it", apiErr.RequestID)
+	}
+	if apiErr.Message != "the workflow was modified since it was last read" {
+		t.Errorf("Message = %q, want Problem.Detail", apiErr.Message)
+	}
+
+	var problem *ProblemError
+	if !errors.As(apiErr, &problem) {
+		t.Fatal("errors.As(apiErr, &problem) = false, want true")
+	}
+	if problem != apiErr.Problem {
+		t.Error("errors.As unwrapped a different *ProblemError than apiErr.Problem")
+	}
+}
+
+func TestProblemErrorError(t *testing.T) {
+	p := &ProblemError{Title: "Stale revision", Detail: "the workflow was modified"}
+	if got := p.Error(); got != "Stale revision: the workflow was modified" {
+		t.Errorf("Error() = %q", got)
+	}
+
+	titleOnly := &ProblemError{Title: "Stale revision"}
+	if got := titleOnly.Error(); got != "Stale revision" {
+		t.Errorf("Error() = %q, want just the title when Detail is empty", got)
+	}
+}
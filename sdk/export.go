@@ -0,0 +1,160 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package sdk
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// ExportFormat selects the on-disk encoding used by ExportConfigurationFindings.
+type ExportFormat int
+
+const (
+	// FormatNDJSON writes one JSON object per line (newline-delimited
+	// JSON / JSON Lines). This is the default and is well suited to
+	// S3/GCS exports that will be bulk-loaded by BigQuery, Athena, or
+	// similar tools.
+	FormatNDJSON ExportFormat = iota
+	// FormatJSONArray writes a single JSON array containing every
+	// finding. Convenient for small exports consumed by tools that
+	// expect one JSON document, at the cost of not being appendable.
+	FormatJSONArray
+	// FormatParquet writes columnar Parquet, suitable for direct
+	// querying from Athena, BigQuery external tables, or Spark.
+	FormatParquet
+)
+
+// ExportOptions configures ExportConfigurationFindings.
+type ExportOptions struct {
+	// Format selects the output encoding. The zero value is FormatNDJSON.
+	Format ExportFormat
+	// Gzip wraps the output in gzip compression when true.
+	Gzip bool
+	// ProgressFunc, if set, is called after each finding is written with
+	// the running total of findings written so far.
+	ProgressFunc func(written int64)
+}
+
+// ExportConfigurationFindings iterates every page of configuration
+// findings matching query and writes them to w in the encoding selected
+// by opts.Format, without holding the full result set in memory. It
+// returns the number of findings written.
+//
+// container scopes the request; pass nil to use the client's default
+// container (see WithDefaultContainer).
+func (c *Client) ExportConfigurationFindings(ctx context.Context, container *ResourceContainer, w io.Writer, query *ConfigurationFindingsQuery, opts ExportOptions) (int64, error) {
+	if opts.Gzip {
+		gw := gzip.NewWriter(w)
+		defer gw.Close()
+		w = gw
+	}
+
+	switch opts.Format {
+	case FormatNDJSON:
+		return c.exportConfigurationFindingsNDJSON(ctx, container, w, query, opts)
+	case FormatJSONArray:
+		return c.exportConfigurationFindingsJSONArray(ctx, container, w, query, opts)
+	case FormatParquet:
+		return c.exportConfigurationFindingsParquet(ctx, container, w, query, opts)
+	default:
+		return 0, fmt.Errorf("unsupported export format: %d", opts.Format)
+	}
+}
+
+func (c *Client) exportConfigurationFindingsNDJSON(ctx context.Context, container *ResourceContainer, w io.Writer, query *ConfigurationFindingsQuery, opts ExportOptions) (int64, error) {
+	enc := json.NewEncoder(w)
+
+	it := c.ListConfigurationFindingsIter(ctx, container, query)
+	defer it.Close()
+
+	var count int64
+	for it.Next() {
+		if err := enc.Encode(it.Value()); err != nil {
+			return count, fmt.Errorf("encoding finding: %w", err)
+		}
+		count++
+		if opts.ProgressFunc != nil {
+			opts.ProgressFunc(count)
+		}
+	}
+
+	return count, it.Err()
+}
+
+func (c *Client) exportConfigurationFindingsJSONArray(ctx context.Context, container *ResourceContainer, w io.Writer, query *ConfigurationFindingsQuery, opts ExportOptions) (int64, error) {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return 0, fmt.Errorf("writing output: %w", err)
+	}
+
+	it := c.ListConfigurationFindingsIter(ctx, container, query)
+	defer it.Close()
+
+	var count int64
+	for it.Next() {
+		if count > 0 {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return count, fmt.Errorf("writing output: %w", err)
+			}
+		}
+		data, err := json.Marshal(it.Value())
+		if err != nil {
+			return count, fmt.Errorf("encoding finding: %w", err)
+		}
+		if _, err := w.Write(data); err != nil {
+			return count, fmt.Errorf("writing output: %w", err)
+		}
+		count++
+		if opts.ProgressFunc != nil {
+			opts.ProgressFunc(count)
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		return count, err
+	}
+
+	if _, err := io.WriteString(w, "]"); err != nil {
+		return count, fmt.Errorf("writing output: %w", err)
+	}
+
+	return count, nil
+}
+
+func (c *Client) exportConfigurationFindingsParquet(ctx context.Context, container *ResourceContainer, w io.Writer, query *ConfigurationFindingsQuery, opts ExportOptions) (int64, error) {
+	pw := parquet.NewGenericWriter[ConfigurationFinding](w)
+
+	it := c.ListConfigurationFindingsIter(ctx, container, query)
+	defer it.Close()
+
+	var count int64
+	for it.Next() {
+		finding := it.Value()
+		if _, err := pw.Write([]ConfigurationFinding{finding}); err != nil {
+			_ = pw.Close()
+			return count, fmt.Errorf("writing parquet row: %w", err)
+		}
+		count++
+		if opts.ProgressFunc != nil {
+			opts.ProgressFunc(count)
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		_ = pw.Close()
+		return count, err
+	}
+
+	if err := pw.Close(); err != nil {
+		return count, fmt.Errorf("closing parquet writer: %w", err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,142 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package sdk
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Filter is a composable predicate that compiles into the API's query
+// filter syntax (field:op:value, combined with and(...)/or(...)/not(...)).
+// Build one with Eq, In, or Between, then combine filters with And, Or,
+// Not, or the equivalent chaining methods:
+//
+//	f := sdk.Eq("severity", sdk.SeverityHigh).
+//		And(sdk.In("category", "malware", "exfiltration")).
+//		And(sdk.Not(sdk.Eq("status", sdk.StatusArchived)))
+type Filter struct {
+	expr string
+}
+
+// String returns the filter's compiled query syntax.
+func (f *Filter) String() string {
+	if f == nil {
+		return ""
+	}
+	return f.expr
+}
+
+// Eq builds a filter matching field exactly equal to value.
+func Eq(field, value string) *Filter {
+	return &Filter{expr: fmt.Sprintf("%s:eq:%s", field, value)}
+}
+
+// In builds a filter matching field against any of values.
+func In(field string, values ...string) *Filter {
+	return &Filter{expr: fmt.Sprintf("%s:in:%s", field, strings.Join(values, "|"))}
+}
+
+// Between builds a filter matching field within [from, to], formatting
+// both timestamps as RFC3339.
+func Between(field string, from, to time.Time) *Filter {
+	return &Filter{expr: fmt.Sprintf("%s:between:%s,%s", field, from.Format(time.RFC3339), to.Format(time.RFC3339))}
+}
+
+// Not negates f.
+func Not(f *Filter) *Filter {
+	return &Filter{expr: fmt.Sprintf("not(%s)", f.String())}
+}
+
+// And combines filters with logical AND. A single filter is returned
+// unwrapped; nil filters are skipped.
+func And(filters ...*Filter) *Filter {
+	return combine("and", filters)
+}
+
+// Or combines filters with logical OR. A single filter is returned
+// unwrapped; nil filters are skipped.
+func Or(filters ...*Filter) *Filter {
+	return combine("or", filters)
+}
+
+func combine(op string, filters []*Filter) *Filter {
+	var exprs []string
+	for _, f := range filters {
+		if f != nil {
+			exprs = append(exprs, f.expr)
+		}
+	}
+	switch len(exprs) {
+	case 0:
+		return nil
+	case 1:
+		return &Filter{expr: exprs[0]}
+	default:
+		return &Filter{expr: fmt.Sprintf("%s(%s)", op, strings.Join(exprs, ","))}
+	}
+}
+
+// And returns f combined with other via logical AND, so filters can be
+// built up by chaining instead of nesting And/Or calls.
+func (f *Filter) And(other *Filter) *Filter {
+	return And(f, other)
+}
+
+// Or returns f combined with other via logical OR.
+func (f *Filter) Or(other *Filter) *Filter {
+	return Or(f, other)
+}
+
+// SortDirection is the direction of a SortField.
+type SortDirection string
+
+const (
+	// SortAscending sorts from lowest to highest.
+	SortAscending SortDirection = "asc"
+	// SortDescending sorts from highest to lowest.
+	SortDescending SortDirection = "desc"
+)
+
+// SortField is one field in a server-side sort order, applied in the
+// order given in a query's SortBy slice.
+type SortField struct {
+	Field     string
+	Direction SortDirection
+}
+
+// String returns the field's compiled sort syntax, e.g. "severity:desc".
+func (s SortField) String() string {
+	direction := s.Direction
+	if direction == "" {
+		direction = SortAscending
+	}
+	return fmt.Sprintf("%s:%s", s.Field, direction)
+}
+
+// encodeSortBy joins sort fields into the API's comma-separated sort
+// syntax, or "" if fields is empty.
+func encodeSortBy(fields []SortField) string {
+	parts := make([]string, len(fields))
+	for i, f := range fields {
+		parts[i] = f.String()
+	}
+	return strings.Join(parts, ",")
+}
+
+// validateSortBy checks that every field in fields has a non-empty name
+// and a recognized direction.
+func validateSortBy(fields []SortField) error {
+	for _, f := range fields {
+		if f.Field == "" {
+			return fmt.Errorf("sort field name is required")
+		}
+		if f.Direction != "" && f.Direction != SortAscending && f.Direction != SortDescending {
+			return fmt.Errorf("invalid sort direction for field %q: %s", f.Field, f.Direction)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package sdk
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFilterString(t *testing.T) {
+	t1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2 := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		filter *Filter
+		want   string
+	}{
+		{"eq", Eq("severity", "HIGH"), "severity:eq:HIGH"},
+		{"in", In("category", "a", "b", "c"), "category:in:a|b|c"},
+		{"between", Between("first_seen_time", t1, t2), "first_seen_time:between:2024-01-01T00:00:00Z,2024-01-02T00:00:00Z"},
+		{"not", Not(Eq("status", "ARCHIVED")), "not(status:eq:ARCHIVED)"},
+		{"and", And(Eq("severity", "HIGH"), Eq("category", "malware")), "and(severity:eq:HIGH,category:eq:malware)"},
+		{"or", Or(Eq("severity", "HIGH"), Eq("severity", "CRITICAL")), "or(severity:eq:HIGH,severity:eq:CRITICAL)"},
+		{"and single collapses", And(Eq("severity", "HIGH")), "severity:eq:HIGH"},
+		{"and empty is nil", And(), ""},
+		{"and skips nil", And(nil, Eq("severity", "HIGH")), "severity:eq:HIGH"},
+		{"chained and", Eq("severity", "HIGH").And(Eq("category", "malware")), "and(severity:eq:HIGH,category:eq:malware)"},
+		{"chained or", Eq("severity", "HIGH").Or(Eq("severity", "CRITICAL")), "or(severity:eq:HIGH,severity:eq:CRITICAL)"},
+		{"nil filter", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortFieldString(t *testing.T) {
+	tests := []struct {
+		name string
+		sf   SortField
+		want string
+	}{
+		{"explicit asc", SortField{Field: "severity", Direction: SortAscending}, "severity:asc"},
+		{"explicit desc", SortField{Field: "first_seen_time", Direction: SortDescending}, "first_seen_time:desc"},
+		{"default direction", SortField{Field: "severity"}, "severity:asc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.sf.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeSortBy(t *testing.T) {
+	got := encodeSortBy([]SortField{
+		{Field: "severity", Direction: SortDescending},
+		{Field: "first_seen_time"},
+	})
+	want := "severity:desc,first_seen_time:asc"
+	if got != want {
+		t.Errorf("encodeSortBy() = %q, want %q", got, want)
+	}
+
+	if got := encodeSortBy(nil); got != "" {
+		t.Errorf("encodeSortBy(nil) = %q, want empty", got)
+	}
+}
+
+func TestValidateSortBy(t *testing.T) {
+	tests := []struct {
+		name    string
+		fields  []SortField
+		wantErr bool
+	}{
+		{"empty", nil, false},
+		{"valid asc", []SortField{{Field: "severity", Direction: SortAscending}}, false},
+		{"valid default direction", []SortField{{Field: "severity"}}, false},
+		{"missing field name", []SortField{{Direction: SortAscending}}, true},
+		{"invalid direction", []SortField{{Field: "severity", Direction: "sideways"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateSortBy(tt.fields); (err != nil) != tt.wantErr {
+				t.Errorf("validateSortBy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package sdk
+
+import (
+	"context"
+	"net/http"
+)
+
+// FindingsIterator walks configuration findings page by page without a
+// background goroutine or channel. Call Next until it returns false, then
+// check Err for any failure. Close releases resources held by the
+// iterator; it is safe to call multiple times. It is a thin wrapper
+// around ItemIterator.
+//
+// Example:
+//
+//	it := client.ListConfigurationFindingsIter(ctx, nil, query)
+//	defer it.Close()
+//	for it.Next() {
+//	    process(it.Value())
+//	}
+//	if err := it.Err(); err != nil {
+//	    log.Fatal(err)
+//	}
+type FindingsIterator struct {
+	it *ItemIterator[ConfigurationFinding]
+}
+
+// ListConfigurationFindingsIter returns a FindingsIterator over
+// configuration findings, scoped by container (nil uses the client's
+// default container). Unlike ListConfigurationFindings, it fetches pages
+// synchronously on demand and does not leak a goroutine if the caller
+// stops iterating early.
+func (c *Client) ListConfigurationFindingsIter(ctx context.Context, container *ResourceContainer, query *ConfigurationFindingsQuery) *FindingsIterator {
+	if query == nil {
+		query = &ConfigurationFindingsQuery{}
+	}
+	container = c.container(container)
+	return &FindingsIterator{
+		it: NewItemIterator(ctx, func(ctx context.Context, pageToken string, header *http.Header) ([]ConfigurationFinding, string, error) {
+			return c.listConfigurationFindingsPage(ctx, container, query, pageToken, header)
+		}),
+	}
+}
+
+// Next advances the iterator, fetching the next page if necessary. It
+// returns false when iteration is complete or an error occurred; check
+// Err to distinguish the two.
+func (it *FindingsIterator) Next() bool {
+	return it.it.Next()
+}
+
+// Value returns the finding at the iterator's current position. It is
+// only valid after a call to Next that returned true.
+func (it *FindingsIterator) Value() ConfigurationFinding {
+	return it.it.Value()
+}
+
+// Page returns the full page of findings the current Value was drawn
+// from. Useful when callers want to batch-process a page at a time
+// instead of one finding at a time.
+func (it *FindingsIterator) Page() []ConfigurationFinding {
+	return it.it.Page()
+}
+
+// Err returns the first error encountered during iteration, if any.
+func (it *FindingsIterator) Err() error {
+	return it.it.Err()
+}
+
+// Close marks the iterator as done, releasing its reference to the
+// current page. It never returns an error; the method exists so
+// FindingsIterator satisfies the same shape as io.Closer for callers
+// that defer it unconditionally.
+func (it *FindingsIterator) Close() error {
+	return it.it.Close()
+}
@@ -0,0 +1,276 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ghsaAdvisory is the shape of a single entry in GitHub's
+// /advisories response (https://docs.github.com/en/rest/security-advisories).
+type ghsaAdvisory struct {
+	GHSAID      string `json:"ghsa_id"`
+	CVEID       string `json:"cve_id"`
+	Summary     string `json:"summary"`
+	Severity    string `json:"severity"`
+	State       string `json:"state"`
+	PublishedAt string `json:"published_at"`
+	UpdatedAt   string `json:"updated_at"`
+	CWEs        []struct {
+		CWEID string `json:"cwe_id"`
+	} `json:"cwes"`
+	Identifiers []struct {
+		Type  string `json:"type"`
+		Value string `json:"value"`
+	} `json:"identifiers"`
+	Vulnerabilities []struct {
+		Package struct {
+			Ecosystem string `json:"ecosystem"`
+			Name      string `json:"name"`
+		} `json:"package"`
+		VulnerableVersionRange string `json:"vulnerable_version_range"`
+		FirstPatchedVersion    *struct {
+			Identifier string `json:"identifier"`
+		} `json:"first_patched_version"`
+	} `json:"vulnerabilities"`
+}
+
+// doGHSARequest executes req against the GHSA API directly through
+// c.httpClient, bypassing doRequest's Upwind auth/rate-limit/retry
+// middleware chain since that chain is only meant for Upwind's own API.
+func (c *Client) doGHSARequest(req *http.Request) (*http.Response, error) {
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if c.config.GHSAToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.config.GHSAToken)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing GHSA request: %w", err)
+	}
+	return resp, nil
+}
+
+// EnrichVulnerability looks up v.NVDCVEID against GitHub's Security
+// Advisories database and populates v.GHSAID, v.Aliases,
+// v.EcosystemAdvisories, and v.VulnerableVersionRanges from the results.
+// It is a no-op if v.NVDCVEID is empty. Enrichment is best-effort: if no
+// matching advisory is found, v is left unchanged and no error is
+// returned.
+func (c *Client) EnrichVulnerability(ctx context.Context, v *Vulnerability) error {
+	if v.NVDCVEID == "" {
+		return nil
+	}
+
+	urlPath := fmt.Sprintf("%s/advisories?cve_id=%s", c.config.GetGHSABaseURL(), url.QueryEscape(v.NVDCVEID))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", urlPath, nil)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	resp, err := c.doGHSARequest(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return decodeError(resp, body)
+	}
+
+	var advisories []ghsaAdvisory
+	if err := json.NewDecoder(resp.Body).Decode(&advisories); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+
+	for _, advisory := range advisories {
+		applyGHSAAdvisory(v, advisory)
+	}
+
+	return nil
+}
+
+// applyGHSAAdvisory merges a single GHSA advisory into v.
+func applyGHSAAdvisory(v *Vulnerability, advisory ghsaAdvisory) {
+	if v.GHSAID == "" {
+		v.GHSAID = advisory.GHSAID
+	}
+
+	v.Aliases = appendUnique(v.Aliases, advisory.CVEID)
+	v.Aliases = appendUnique(v.Aliases, advisory.GHSAID)
+	for _, id := range advisory.Identifiers {
+		v.Aliases = appendUnique(v.Aliases, id.Value)
+	}
+
+	cweIDs := make([]string, 0, len(advisory.CWEs))
+	for _, cwe := range advisory.CWEs {
+		cweIDs = append(cweIDs, cwe.CWEID)
+	}
+	v.EcosystemAdvisories = append(v.EcosystemAdvisories, EcosystemAdvisory{
+		GHSAID:      advisory.GHSAID,
+		Summary:     advisory.Summary,
+		Severity:    advisory.Severity,
+		State:       advisory.State,
+		CWEIDs:      cweIDs,
+		PublishedAt: advisory.PublishedAt,
+		UpdatedAt:   advisory.UpdatedAt,
+	})
+
+	for _, vuln := range advisory.Vulnerabilities {
+		fixed := ""
+		if vuln.FirstPatchedVersion != nil {
+			fixed = vuln.FirstPatchedVersion.Identifier
+		}
+		introduced, lastAffected := parseGHSAVersionRange(vuln.VulnerableVersionRange)
+		v.VulnerableVersionRanges = append(v.VulnerableVersionRanges, VersionRange{
+			Ecosystem:    vuln.Package.Ecosystem,
+			Introduced:   introduced,
+			Fixed:        fixed,
+			LastAffected: lastAffected,
+		})
+	}
+}
+
+// parseGHSAVersionRange splits a GHSA vulnerable_version_range expression
+// (e.g. ">= 1.0.0, < 1.2.3") into the single introduced and last-affected
+// versions VersionRange expects. introduced is the lower bound (">=" or
+// "="); lastAffected is an inclusive upper bound ("<="). An exclusive
+// upper bound ("<") is not returned here, since it duplicates the
+// first_patched_version GHSA already reports as Fixed. Constraints this
+// function doesn't recognize are silently dropped rather than guessed at.
+func parseGHSAVersionRange(raw string) (introduced, lastAffected string) {
+	for _, constraint := range strings.Split(raw, ",") {
+		constraint = strings.TrimSpace(constraint)
+		switch {
+		case strings.HasPrefix(constraint, ">="):
+			introduced = strings.TrimSpace(strings.TrimPrefix(constraint, ">="))
+		case strings.HasPrefix(constraint, "<="):
+			lastAffected = strings.TrimSpace(strings.TrimPrefix(constraint, "<="))
+		case strings.HasPrefix(constraint, "="):
+			introduced = strings.TrimSpace(strings.TrimPrefix(constraint, "="))
+		}
+	}
+	return introduced, lastAffected
+}
+
+func appendUnique(values []string, value string) []string {
+	if value == "" {
+		return values
+	}
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
+
+// RepositoryAdvisoriesQuery represents query parameters for
+// ListRepositoryAdvisoriesForOrg.
+type RepositoryAdvisoriesQuery struct {
+	Severity string
+	State    string
+	PerPage  int
+}
+
+// Encode implements QueryEncoder, building the URL-encoded query string
+// for a single page of repository advisories.
+func (q RepositoryAdvisoriesQuery) Encode(pageToken string) string {
+	params := url.Values{}
+
+	if pageToken != "" {
+		params.Add("page", pageToken)
+	}
+	if q.Severity != "" {
+		params.Add("severity", q.Severity)
+	}
+	if q.State != "" {
+		params.Add("state", q.State)
+	}
+	if q.PerPage > 0 {
+		params.Add("per_page", fmt.Sprintf("%d", q.PerPage))
+	}
+
+	return params.Encode()
+}
+
+// listRepositoryAdvisoriesForOrgPage retrieves a single page of an
+// organization's published repository security advisories.
+func (c *Client) listRepositoryAdvisoriesForOrgPage(ctx context.Context, org string, query RepositoryAdvisoriesQuery, pageToken string, header *http.Header) ([]EcosystemAdvisory, string, error) {
+	urlPath := fmt.Sprintf("%s/orgs/%s/security-advisories", c.config.GetGHSABaseURL(), url.PathEscape(org))
+	if queryParams := query.Encode(pageToken); queryParams != "" {
+		urlPath += "?" + queryParams
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", urlPath, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("creating request: %w", err)
+	}
+
+	resp, err := c.doGHSARequest(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	if header != nil {
+		*header = resp.Header
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, "", decodeError(resp, body)
+	}
+
+	var advisories []ghsaAdvisory
+	if err := json.NewDecoder(resp.Body).Decode(&advisories); err != nil {
+		return nil, "", fmt.Errorf("decoding response: %w", err)
+	}
+
+	results := make([]EcosystemAdvisory, 0, len(advisories))
+	for _, advisory := range advisories {
+		cweIDs := make([]string, 0, len(advisory.CWEs))
+		for _, cwe := range advisory.CWEs {
+			cweIDs = append(cweIDs, cwe.CWEID)
+		}
+		results = append(results, EcosystemAdvisory{
+			GHSAID:      advisory.GHSAID,
+			Summary:     advisory.Summary,
+			Severity:    advisory.Severity,
+			State:       advisory.State,
+			CWEIDs:      cweIDs,
+			PublishedAt: advisory.PublishedAt,
+			UpdatedAt:   advisory.UpdatedAt,
+		})
+	}
+
+	nextToken, err := extractNextLink(resp.Header.Get("Link"))
+	if err != nil {
+		return nil, "", fmt.Errorf("parsing pagination link: %w", err)
+	}
+	if nextToken != "" {
+		if parsedURL, err := url.Parse(nextToken); err == nil {
+			nextToken = parsedURL.Query().Get("page")
+		}
+	}
+
+	return results, nextToken, nil
+}
+
+// ListRepositoryAdvisoriesForOrg returns a Paginator over org's
+// published GitHub repository security advisories.
+func (c *Client) ListRepositoryAdvisoriesForOrg(ctx context.Context, org string) *Paginator[EcosystemAdvisory] {
+	return NewPaginator(func(ctx context.Context, pageToken string, header *http.Header) ([]EcosystemAdvisory, string, error) {
+		return c.listRepositoryAdvisoriesForOrgPage(ctx, org, RepositoryAdvisoriesQuery{}, pageToken, header)
+	})
+}
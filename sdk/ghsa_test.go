@@ -0,0 +1,62 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package sdk
+
+import "testing"
+
+func TestApplyGHSAAdvisory(t *testing.T) {
+	v := &Vulnerability{NVDCVEID: "CVE-2024-0001"}
+
+	advisory := ghsaAdvisory{
+		GHSAID:   "GHSA-xxxx-yyyy-zzzz",
+		CVEID:    "CVE-2024-0001",
+		Summary:  "example advisory",
+		Severity: "high",
+		State:    "published",
+	}
+	advisory.CWEs = append(advisory.CWEs, struct {
+		CWEID string `json:"cwe_id"`
+	}{CWEID: "CWE-79"})
+	advisory.Vulnerabilities = append(advisory.Vulnerabilities, struct {
+		Package struct {
+			Ecosystem string `json:"ecosystem"`
+			Name      string `json:"name"`
+		} `json:"package"`
+		VulnerableVersionRange string `json:"vulnerable_version_range"`
+		FirstPatchedVersion    *struct {
+			Identifier string `json:"identifier"`
+		} `json:"first_patched_version"`
+	}{
+		VulnerableVersionRange: "< 1.2.3",
+	})
+	advisory.Vulnerabilities[0].Package.Ecosystem = "npm"
+	advisory.Vulnerabilities[0].Package.Name = "example-pkg"
+
+	applyGHSAAdvisory(v, advisory)
+
+	if v.GHSAID != "GHSA-xxxx-yyyy-zzzz" {
+		t.Errorf("GHSAID = %q, want GHSA-xxxx-yyyy-zzzz", v.GHSAID)
+	}
+	if len(v.Aliases) != 2 {
+		t.Errorf("Aliases = %v, want 2 entries", v.Aliases)
+	}
+	if len(v.EcosystemAdvisories) != 1 || v.EcosystemAdvisories[0].Severity != "high" {
+		t.Errorf("EcosystemAdvisories = %+v, want one high-severity entry", v.EcosystemAdvisories)
+	}
+	if len(v.VulnerableVersionRanges) != 1 || v.VulnerableVersionRanges[0].Ecosystem != "npm" {
+		t.Errorf("VulnerableVersionRanges = %+v, want one npm entry", v.VulnerableVersionRanges)
+	}
+}
+
+func TestAppendUnique(t *testing.T) {
+	values := appendUnique(nil, "a")
+	values = appendUnique(values, "a")
+	values = appendUnique(values, "b")
+	values = appendUnique(values, "")
+
+	if len(values) != 2 {
+		t.Errorf("appendUnique() = %v, want [a b]", values)
+	}
+}
@@ -8,7 +8,11 @@ import (
 	"context"
 )
 
-// CollectAll is a helper function that collects all items from a channel into a slice
+// CollectAll is a helper function that collects all items from a channel
+// into a slice. It stays the simple-use-case path for endpoints with a
+// cursored variant (e.g. ListApiEndpointsWithCursor): use it when you
+// just want every result in memory, and the cursored channel when a
+// long-running export needs to checkpoint its progress across restarts.
 func CollectAll[T any](ctx context.Context, itemsCh <-chan T, errCh <-chan error) ([]T, error) {
 	var results []T
 
@@ -0,0 +1,111 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package sdk
+
+import (
+	"context"
+	"net/http"
+)
+
+// IntegrationWebhookIterator streams integration webhooks, fetching
+// lazily instead of buffering the full result set the way
+// ListIntegrationWebhooks does. The underlying endpoint has no
+// server-side pagination, so iteration fetches a single page on the
+// first call to Next and then drains it; see ThreatEventIterator for
+// the shape this mirrors.
+type IntegrationWebhookIterator struct {
+	itemsCh <-chan IntegrationWebhook
+	errCh   <-chan error
+	cancel  context.CancelFunc
+
+	current IntegrationWebhook
+	err     error
+	closed  bool
+}
+
+// IterateIntegrationWebhooks returns an IntegrationWebhookIterator over
+// integration webhooks, optionally filtered by vendor. No request is
+// made until the first call to Next, Webhooks, or Errors.
+func (c *Client) IterateIntegrationWebhooks(ctx context.Context, vendor string) *IntegrationWebhookIterator {
+	ctx, cancel := context.WithCancel(ctx)
+	paginator := NewPaginator(func(ctx context.Context, pageToken string, header *http.Header) ([]IntegrationWebhook, string, error) {
+		webhooks, err := c.listIntegrationWebhooksPage(ctx, vendor, header)
+		if err != nil {
+			return nil, "", err
+		}
+		return webhooks, "", nil
+	})
+	itemsCh, errCh := paginator.Stream(ctx)
+
+	return &IntegrationWebhookIterator{
+		itemsCh: itemsCh,
+		errCh:   errCh,
+		cancel:  cancel,
+	}
+}
+
+// Next advances the iterator, blocking until the next webhook is
+// available, the underlying fetch fails, or ctx is canceled. It returns
+// false when iteration is complete; check Err to distinguish a clean
+// end from a failure.
+func (it *IntegrationWebhookIterator) Next() bool {
+	if it.closed {
+		return false
+	}
+
+	select {
+	case webhook, ok := <-it.itemsCh:
+		if !ok {
+			select {
+			case err := <-it.errCh:
+				it.err = err
+			default:
+			}
+			return false
+		}
+		it.current = webhook
+		return true
+	case err := <-it.errCh:
+		if err != nil {
+			it.err = err
+		}
+		return false
+	}
+}
+
+// Webhook returns the webhook at the iterator's current position. It is
+// only valid after a call to Next that returned true.
+func (it *IntegrationWebhookIterator) Webhook() IntegrationWebhook {
+	return it.current
+}
+
+// Err returns the first error encountered during iteration, if any.
+func (it *IntegrationWebhookIterator) Err() error {
+	return it.err
+}
+
+// Webhooks exposes the iterator's underlying item channel, for callers
+// that prefer the SDK's channel-based convention, e.g.
+// CollectAll(ctx, iter.Webhooks(), iter.Errors()).
+func (it *IntegrationWebhookIterator) Webhooks() <-chan IntegrationWebhook {
+	return it.itemsCh
+}
+
+// Errors exposes the iterator's underlying error channel; see Webhooks.
+func (it *IntegrationWebhookIterator) Errors() <-chan error {
+	return it.errCh
+}
+
+// Close stops the iterator's background fetch. It is safe to call
+// multiple times and never returns an error; the method exists so
+// IntegrationWebhookIterator satisfies the same shape as io.Closer for
+// callers that defer it unconditionally.
+func (it *IntegrationWebhookIterator) Close() error {
+	if !it.closed {
+		it.closed = true
+		it.cancel()
+	}
+	return nil
+}
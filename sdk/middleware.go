@@ -0,0 +1,254 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RoundTripFunc executes a single HTTP request and returns its response,
+// analogous to http.RoundTripper but as a plain function value.
+type RoundTripFunc func(*http.Request) (*http.Response, error)
+
+// Middleware wraps a RoundTripFunc to observe or modify requests and
+// responses. Middlewares compose like http.Handler wrappers: the
+// returned RoundTripFunc should call next to continue the chain.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// WithMiddleware registers one or more middlewares on a Client, in the
+// order given. Middlewares wrap the SDK's built-in auth injection, rate
+// limiting, and retry behavior, so they see one call per logical
+// request (including all of its internal retries).
+func WithMiddleware(mw ...Middleware) ClientOption {
+	return func(c *Client) {
+		c.middlewares = append(c.middlewares, mw...)
+	}
+}
+
+// WithHeader returns a Middleware that sets the given header on every
+// outgoing request, computing its value from fn. Useful for correlation
+// IDs and other per-request metadata:
+//
+//	sdk.WithHeader("X-Correlation-ID", func(*http.Request) string {
+//	    return uuid.NewString()
+//	})
+func WithHeader(name string, fn func(*http.Request) string) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			req.Header.Set(name, fn(req))
+			return next(req)
+		}
+	}
+}
+
+// buildTransport composes the client's request pipeline: user
+// middlewares (outermost, see WithMiddleware) wrapping the built-in
+// retry loop, which wraps rate limiting, which wraps auth injection,
+// which wraps the underlying HTTPClient.
+func (c *Client) buildTransport() RoundTripFunc {
+	base := RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return c.httpClient.Do(req)
+	})
+
+	chain := c.retryMiddleware()(c.rateLimitMiddleware()(c.authMiddleware()(base)))
+
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		chain = c.middlewares[i](chain)
+	}
+
+	return chain
+}
+
+// authMiddleware attaches the OAuth2 bearer token and User-Agent header,
+// forcing a token refresh and a single retry on a 401 response.
+func (c *Client) authMiddleware() Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			token, err := c.getToken(req.Context())
+			if err != nil {
+				return nil, fmt.Errorf("token error: %w", err)
+			}
+
+			req = req.Clone(req.Context())
+			req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+			req.Header.Set("User-Agent", UserAgent())
+
+			c.logger.Logf("%s %s", req.Method, req.URL)
+
+			resp, err := next(req)
+			if err != nil {
+				return nil, err
+			}
+
+			if resp.StatusCode == http.StatusUnauthorized {
+				c.tokenMu.Lock()
+				c.token = nil
+				c.tokenMu.Unlock()
+			}
+
+			return resp, nil
+		}
+	}
+}
+
+// rateLimitMiddleware blocks until the client's token bucket admits the
+// request.
+func (c *Client) rateLimitMiddleware() Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			if err := c.limiter.Wait(req.Context()); err != nil {
+				return nil, fmt.Errorf("rate limiter: %w", err)
+			}
+			return next(req)
+		}
+	}
+}
+
+// retryMiddleware retries the request up to config.MaxRetries times on
+// transport errors, 401 (after the auth middleware has cleared the
+// cached token), and the statuses in config.RetryableStatuses (429 and
+// 503 by default), honoring Retry-After. Except for the 401 case, which
+// is always retried once to pick up a refreshed token, POST and PATCH
+// requests are left alone unless config.RetryNonIdempotent is set, since
+// a request that failed after reaching the server may have already
+// taken effect.
+func (c *Client) retryMiddleware() Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			ctx := req.Context()
+			idempotent := c.config.RetryNonIdempotent || (req.Method != http.MethodPost && req.Method != http.MethodPatch)
+
+			var lastErr error
+
+			for i := 0; i <= c.config.MaxRetries; i++ {
+				resp, err := next(req)
+				if err != nil {
+					if !idempotent {
+						return nil, err
+					}
+					lastErr = err
+					if !sleepOrBail(ctx, c.backoff(i, "")) {
+						return nil, ctx.Err()
+					}
+					continue
+				}
+
+				if resp.StatusCode == http.StatusUnauthorized && i < c.config.MaxRetries {
+					body, _ := io.ReadAll(resp.Body)
+					_ = resp.Body.Close()
+					apiErr := decodeError(resp, body)
+					lastErr = apiErr
+					if !sleepOrBail(ctx, c.backoff(i, "")) {
+						return nil, ctx.Err()
+					}
+					continue
+				}
+
+				if idempotent && isRetryableStatus(resp.StatusCode, c.config.GetRetryableStatuses()) && i < c.config.MaxRetries {
+					body, _ := io.ReadAll(resp.Body)
+					_ = resp.Body.Close()
+					apiErr := decodeError(resp, body)
+					lastErr = apiErr
+
+					delay := c.backoff(i, "")
+					if c.config.RespectRetryAfterEnabled() && apiErr.RetryAfter > 0 {
+						delay = capDuration(apiErr.RetryAfter, c.config.GetMaxRetryAfter())
+					}
+					if !sleepOrBail(ctx, delay) {
+						return nil, ctx.Err()
+					}
+					continue
+				}
+
+				return resp, nil
+			}
+
+			return nil, fmt.Errorf("all retries failed: %w", lastErr)
+		}
+	}
+}
+
+// isRetryableStatus reports whether status appears in statuses.
+func isRetryableStatus(status int, statuses []int) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff computes how long to wait before the next retry attempt. When
+// retryAfter (the Retry-After header value, in seconds or HTTP-date form)
+// is present, parses cleanly, and config.RespectRetryAfterEnabled() is
+// true, it takes precedence, capped at config.GetMaxRetryAfter().
+// Otherwise it falls back to exponential backoff with full jitter,
+// capped at MaxBackoff.
+func (c *Client) backoff(attempt int, retryAfter string) time.Duration {
+	if retryAfter != "" && c.config.RespectRetryAfterEnabled() {
+		if d, ok := parseRetryAfter(retryAfter); ok {
+			return capDuration(d, c.config.GetMaxRetryAfter())
+		}
+	}
+
+	maxBackoff := c.config.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = 30 * time.Second
+	}
+
+	sleep := c.config.GetBaseBackoff() * time.Duration(1<<uint(attempt))
+	sleep = capDuration(sleep, maxBackoff)
+
+	if !c.config.JitterEnabled() {
+		return sleep
+	}
+	return time.Duration(rand.Int63n(int64(sleep) + 1))
+}
+
+// parseRetryAfter parses a Retry-After header value in either the
+// seconds or HTTP-date form.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(value); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+
+	return 0, false
+}
+
+func capDuration(d, max time.Duration) time.Duration {
+	if max > 0 && d > max {
+		return max
+	}
+	return d
+}
+
+// sleepOrBail waits for d, returning false early if ctx is canceled first.
+func sleepOrBail(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
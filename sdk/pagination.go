@@ -0,0 +1,223 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package sdk
+
+import (
+	"context"
+	"net/http"
+)
+
+// QueryEncoder is implemented by the *Query type of each paginated List
+// endpoint. Encode returns the URL-encoded query string for a single
+// page request, given the page token for that page (empty for the
+// first page). Implementing this once per query type replaces hand
+// building the query string inline in each List method.
+type QueryEncoder interface {
+	Encode(pageToken string) string
+}
+
+// CursoredBatch is one page of T alongside the opaque token that fetches
+// the next page, for callers of a cursored list variant (e.g.
+// ListApiEndpointsWithCursor) who need to checkpoint progress across
+// process restarts. A caller persists NextPageToken after fully
+// processing Items and passes it back as the query's PageToken field on
+// the next run; an empty NextPageToken means there is no next page.
+type CursoredBatch[T any] struct {
+	Items         []T
+	NextPageToken string
+}
+
+// PageFetcher retrieves one page of T. It writes the page's response
+// headers to header when non-nil, so Paginator callers can inspect
+// things like rate-limit headers or request IDs. nextPageToken is the
+// opaque token to pass to the next call, or "" if there are no more
+// pages; it may come from a Link header or a cursor field in the
+// response body - PageFetcher implementations hide that distinction.
+type PageFetcher[T any] func(ctx context.Context, pageToken string, header *http.Header) (items []T, nextPageToken string, err error)
+
+// Paginator walks a paginated API endpoint one page at a time, without
+// loading the full result set into memory. It is the shared
+// implementation behind the SDK's List* methods; see All and Stream for
+// the two ways to consume it, or call Next directly to control paging
+// yourself.
+type Paginator[T any] struct {
+	fetch      PageFetcher[T]
+	nextToken  string
+	done       bool
+	lastHeader http.Header
+}
+
+// NewPaginator returns a Paginator that fetches pages using fetch.
+func NewPaginator[T any](fetch PageFetcher[T]) *Paginator[T] {
+	return &Paginator[T]{fetch: fetch}
+}
+
+// HasNext reports whether a call to Next is expected to return more
+// items. It is false once the underlying endpoint has reported no
+// further pages, or after Next has returned an error.
+func (p *Paginator[T]) HasNext() bool {
+	return !p.done
+}
+
+// Next fetches and returns the next page of items.
+func (p *Paginator[T]) Next(ctx context.Context) ([]T, error) {
+	if p.done {
+		return nil, nil
+	}
+
+	items, nextToken, err := p.fetch(ctx, p.nextToken, &p.lastHeader)
+	if err != nil {
+		p.done = true
+		return nil, err
+	}
+
+	p.nextToken = nextToken
+	if nextToken == "" {
+		p.done = true
+	}
+
+	return items, nil
+}
+
+// LastHeader returns the response headers from the most recently
+// fetched page, for observability. It is nil until the first call to
+// Next.
+func (p *Paginator[T]) LastHeader() http.Header {
+	return p.lastHeader
+}
+
+// All drains the paginator into a single slice. Prefer Next or Stream
+// when the result set may be too large to hold in memory at once.
+func (p *Paginator[T]) All(ctx context.Context) ([]T, error) {
+	var all []T
+	for p.HasNext() {
+		items, err := p.Next(ctx)
+		if err != nil {
+			return all, err
+		}
+		all = append(all, items...)
+	}
+	return all, nil
+}
+
+// Stream adapts the paginator to the SDK's channel-based streaming
+// convention (see ListConfigurationFindings), closing both channels
+// when iteration completes or ctx is canceled.
+func (p *Paginator[T]) Stream(ctx context.Context) (<-chan T, <-chan error) {
+	itemsCh := make(chan T, 100)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(itemsCh)
+		defer close(errCh)
+
+		for p.HasNext() {
+			items, err := p.Next(ctx)
+			if err != nil {
+				errCh <- err
+				return
+			}
+			for _, item := range items {
+				select {
+				case itemsCh <- item:
+				case <-ctx.Done():
+					errCh <- ctx.Err()
+					return
+				}
+			}
+		}
+	}()
+
+	return itemsCh, errCh
+}
+
+// ItemIterator walks a paginated endpoint item by item, fetching one
+// page at a time on demand and never buffering more than the current
+// page. It is the shared implementation behind the SDK's synchronous
+// "Iter" types (ApiEndpointsIterator, FindingsIterator, ...); those
+// types embed an ItemIterator and re-expose its methods under their own
+// element type so callers don't need to touch generics directly.
+type ItemIterator[T any] struct {
+	ctx   context.Context
+	fetch PageFetcher[T]
+
+	page      []T
+	pageIndex int
+	pageToken string
+	started   bool
+	done      bool
+	err       error
+}
+
+// NewItemIterator returns an ItemIterator that fetches pages using
+// fetch, starting from fetch's first page on the first call to Next.
+func NewItemIterator[T any](ctx context.Context, fetch PageFetcher[T]) *ItemIterator[T] {
+	return &ItemIterator[T]{ctx: ctx, fetch: fetch}
+}
+
+// Next advances the iterator, fetching the next page if necessary. It
+// returns false when iteration is complete or an error occurred; check
+// Err to distinguish the two.
+func (it *ItemIterator[T]) Next() bool {
+	if it.done {
+		return false
+	}
+
+	it.pageIndex++
+	if it.pageIndex < len(it.page) {
+		return true
+	}
+
+	if it.started && it.pageToken == "" {
+		it.done = true
+		return false
+	}
+	it.started = true
+
+	page, nextToken, err := it.fetch(it.ctx, it.pageToken, nil)
+	if err != nil {
+		it.err = err
+		it.done = true
+		return false
+	}
+
+	it.page = page
+	it.pageIndex = 0
+	it.pageToken = nextToken
+
+	if len(page) == 0 {
+		if nextToken == "" {
+			it.done = true
+			return false
+		}
+		return it.Next()
+	}
+
+	return true
+}
+
+// Value returns the item at the iterator's current position. It is
+// only valid after a call to Next that returned true.
+func (it *ItemIterator[T]) Value() T {
+	return it.page[it.pageIndex]
+}
+
+// Page returns the full page of items the current Value was drawn from.
+func (it *ItemIterator[T]) Page() []T {
+	return it.page
+}
+
+// Err returns the first error encountered during iteration, if any.
+func (it *ItemIterator[T]) Err() error {
+	return it.err
+}
+
+// Close marks the iterator as done, releasing its reference to the
+// current page. It is safe to call multiple times.
+func (it *ItemIterator[T]) Close() error {
+	it.done = true
+	it.page = nil
+	return nil
+}
@@ -0,0 +1,93 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package sdk
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestProfileFileWriteAndLoadYAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "config.yaml")
+	pf := &ProfileFile{
+		Config:  Config{Region: RegionEU, MaxRetries: 5},
+		Current: "dev",
+		Profiles: map[string]*Config{
+			"dev": {ClientID: "dev-id"},
+		},
+	}
+
+	if err := pf.WriteFile(path); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	loaded, err := LoadProfileFile(path)
+	if err != nil {
+		t.Fatalf("LoadProfileFile() error = %v", err)
+	}
+	if loaded.Region != RegionEU {
+		t.Errorf("Region = %q, want EU", loaded.Region)
+	}
+	if loaded.Current != "dev" {
+		t.Errorf("Current = %q, want dev", loaded.Current)
+	}
+	if loaded.Profiles["dev"].ClientID != "dev-id" {
+		t.Errorf("Profiles[dev].ClientID = %q, want dev-id", loaded.Profiles["dev"].ClientID)
+	}
+}
+
+func TestProfileFileWriteAndLoadJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	pf := &ProfileFile{
+		Config:   Config{Region: RegionME},
+		Current:  "prod",
+		Profiles: map[string]*Config{"prod": {ClientID: "prod-id"}},
+	}
+
+	if err := pf.WriteFile(path); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	loaded, err := LoadProfileFile(path)
+	if err != nil {
+		t.Fatalf("LoadProfileFile() error = %v", err)
+	}
+	if loaded.Profiles["prod"].ClientID != "prod-id" {
+		t.Errorf("Profiles[prod].ClientID = %q, want prod-id", loaded.Profiles["prod"].ClientID)
+	}
+}
+
+func TestProfileFileProfileMergesOverDefaults(t *testing.T) {
+	pf := &ProfileFile{
+		Config: Config{Region: RegionUS, MaxRetries: 3},
+		Profiles: map[string]*Config{
+			"staging": {ClientID: "staging-id", Region: RegionEU},
+		},
+	}
+
+	cfg, err := pf.Profile("staging")
+	if err != nil {
+		t.Fatalf("Profile() error = %v", err)
+	}
+	if cfg.ClientID != "staging-id" {
+		t.Errorf("ClientID = %q, want staging-id", cfg.ClientID)
+	}
+	if cfg.Region != RegionEU {
+		t.Errorf("Region = %q, want EU (overlay wins)", cfg.Region)
+	}
+	if cfg.MaxRetries != 3 {
+		t.Errorf("MaxRetries = %d, want 3 (inherited from defaults)", cfg.MaxRetries)
+	}
+	if cfg.Profile != "staging" {
+		t.Errorf("Profile = %q, want staging", cfg.Profile)
+	}
+}
+
+func TestProfileFileProfileUnknownName(t *testing.T) {
+	pf := &ProfileFile{Profiles: map[string]*Config{}}
+	if _, err := pf.Profile("missing"); err == nil {
+		t.Error("Profile() error = nil, want an error for an unknown profile")
+	}
+}
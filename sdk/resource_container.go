@@ -0,0 +1,36 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package sdk
+
+import "fmt"
+
+// ResourceContainer scopes an API call to a specific organization, cloud
+// account, or project, so a single authenticated Client can address
+// multiple scopes without being permanently pinned to one organization.
+type ResourceContainer struct {
+	level      string
+	identifier string
+}
+
+// OrgResource scopes a request to an organization.
+func OrgResource(organizationID string) *ResourceContainer {
+	return &ResourceContainer{level: "organizations", identifier: organizationID}
+}
+
+// CloudAccountResource scopes a request to a cloud account.
+func CloudAccountResource(cloudAccountID string) *ResourceContainer {
+	return &ResourceContainer{level: "cloud-accounts", identifier: cloudAccountID}
+}
+
+// ProjectResource scopes a request to a project.
+func ProjectResource(projectID string) *ResourceContainer {
+	return &ResourceContainer{level: "projects", identifier: projectID}
+}
+
+// URLFragment renders the container as the path segment API requests are
+// built from, e.g. "organizations/org-abc".
+func (r *ResourceContainer) URLFragment() string {
+	return fmt.Sprintf("%s/%s", r.level, r.identifier)
+}
@@ -0,0 +1,367 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	cyclonedx "github.com/CycloneDX/cyclonedx-go"
+	spdxjson "github.com/spdx/tools-golang/json"
+	common "github.com/spdx/tools-golang/spdx/v2/common"
+	spdx "github.com/spdx/tools-golang/spdx/v2/v2_3"
+	spdxtv "github.com/spdx/tools-golang/spdx/v2/v2_3/tagvalue/writer"
+)
+
+// SbomScope selects how ExportSbomCycloneDXByScope and
+// ExportSbomSPDXByScope group SbomPackage results into one or more
+// documents.
+type SbomScope int
+
+const (
+	// ScopeOrganization aggregates every matching package into a single document.
+	ScopeOrganization SbomScope = iota
+	// ScopeImage produces one document per container image, discovered
+	// from vulnerability findings when filter.ImageName is empty.
+	ScopeImage
+)
+
+// ExportSbomCycloneDX aggregates every SbomPackage matching filter into a
+// single CycloneDX BOM, joining vulnerability findings by package
+// name+version to populate bom.Vulnerabilities.
+func (c *Client) ExportSbomCycloneDX(ctx context.Context, filter SbomPackagesQuery) (*cyclonedx.BOM, error) {
+	packages, err := c.ListSbomPackages(ctx, &filter)
+	if err != nil {
+		return nil, fmt.Errorf("listing SBOM packages: %w", err)
+	}
+
+	vulnsByPackage, err := c.vulnerabilityFindingsByPackage(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing vulnerability findings: %w", err)
+	}
+
+	bom := cyclonedx.NewBOM()
+	components := make([]cyclonedx.Component, 0, len(packages))
+	var vulnerabilities []cyclonedx.Vulnerability
+
+	for _, pkg := range packages {
+		ref := componentRef(pkg)
+		comp := cyclonedx.Component{
+			BOMRef:     ref,
+			Type:       cyclonedx.ComponentTypeLibrary,
+			Name:       pkg.Name,
+			Version:    pkg.Version,
+			PackageURL: purlForPackage(pkg),
+		}
+		if len(pkg.Licenses) > 0 {
+			choices := make(cyclonedx.Licenses, 0, len(pkg.Licenses))
+			for _, lic := range pkg.Licenses {
+				choices = append(choices, cyclonedx.LicenseChoice{Expression: lic})
+			}
+			comp.Licenses = &choices
+		}
+		components = append(components, comp)
+
+		for _, finding := range vulnsByPackage[packageKey(pkg.Name, pkg.Version)] {
+			if vuln, ok := cyclonedxVulnerability(finding, ref); ok {
+				vulnerabilities = append(vulnerabilities, vuln)
+			}
+		}
+	}
+
+	bom.Components = &components
+	if len(vulnerabilities) > 0 {
+		bom.Vulnerabilities = &vulnerabilities
+	}
+
+	return bom, nil
+}
+
+// ExportSbomCycloneDXByScope runs ExportSbomCycloneDX once (scope ==
+// ScopeOrganization) or once per container image (scope == ScopeImage),
+// returning a BOM keyed by image name ("" for the organization-wide
+// BOM).
+func (c *Client) ExportSbomCycloneDXByScope(ctx context.Context, filter SbomPackagesQuery, scope SbomScope) (map[string]*cyclonedx.BOM, error) {
+	images, err := c.scopeImageNames(ctx, filter, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	boms := make(map[string]*cyclonedx.BOM, len(images))
+	for _, image := range images {
+		scoped := filter
+		scoped.ImageName = image
+		bom, err := c.ExportSbomCycloneDX(ctx, scoped)
+		if err != nil {
+			return nil, fmt.Errorf("building BOM for image %q: %w", image, err)
+		}
+		boms[image] = bom
+	}
+
+	return boms, nil
+}
+
+// ExportSbomSPDX aggregates every SbomPackage matching filter into a
+// single SPDX 2.3 document, joining vulnerability findings by package
+// name+version into SPDX relationships.
+func (c *Client) ExportSbomSPDX(ctx context.Context, filter SbomPackagesQuery) (*spdx.Document, error) {
+	packages, err := c.ListSbomPackages(ctx, &filter)
+	if err != nil {
+		return nil, fmt.Errorf("listing SBOM packages: %w", err)
+	}
+
+	vulnsByPackage, err := c.vulnerabilityFindingsByPackage(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing vulnerability findings: %w", err)
+	}
+
+	doc := &spdx.Document{
+		SPDXVersion:       spdx.Version,
+		DataLicense:       spdx.DataLicense,
+		SPDXIdentifier:    "DOCUMENT",
+		DocumentName:      "upwind-sbom",
+		DocumentNamespace: "https://upwind.io/spdxdocs/" + filter.ImageName,
+	}
+
+	for _, pkg := range packages {
+		id := spdxPackageID(pkg)
+		spdxPkg := &spdx.Package{
+			PackageSPDXIdentifier:   common.ElementID(id),
+			PackageName:             pkg.Name,
+			PackageVersion:          pkg.Version,
+			PackageDownloadLocation: "NOASSERTION",
+			PackageLicenseConcluded: spdxLicenseExpression(pkg.Licenses),
+			PackageLicenseDeclared:  spdxLicenseExpression(pkg.Licenses),
+			PackageExternalReferences: []*spdx.PackageExternalReference{
+				{
+					Category: "PACKAGE-MANAGER",
+					RefType:  "purl",
+					Locator:  purlForPackage(pkg),
+				},
+			},
+		}
+		doc.Packages = append(doc.Packages, spdxPkg)
+		doc.Relationships = append(doc.Relationships, &spdx.Relationship{
+			RefA:         common.MakeDocElementID("", "DOCUMENT"),
+			RefB:         common.MakeDocElementID("", id),
+			Relationship: "DESCRIBES",
+		})
+
+		for _, finding := range vulnsByPackage[packageKey(pkg.Name, pkg.Version)] {
+			vulnID := spdxVulnerabilityID(finding)
+			doc.Relationships = append(doc.Relationships, &spdx.Relationship{
+				RefA:                common.MakeDocElementID("", id),
+				RefB:                common.MakeDocElementID("", vulnID),
+				Relationship:        "HAS_PREREQUISITE",
+				RelationshipComment: fmt.Sprintf("affected by %s", vulnerabilityName(finding)),
+			})
+		}
+	}
+
+	return doc, nil
+}
+
+// ExportSbomSPDXByScope is the SPDX equivalent of
+// ExportSbomCycloneDXByScope.
+func (c *Client) ExportSbomSPDXByScope(ctx context.Context, filter SbomPackagesQuery, scope SbomScope) (map[string]*spdx.Document, error) {
+	images, err := c.scopeImageNames(ctx, filter, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make(map[string]*spdx.Document, len(images))
+	for _, image := range images {
+		scoped := filter
+		scoped.ImageName = image
+		doc, err := c.ExportSbomSPDX(ctx, scoped)
+		if err != nil {
+			return nil, fmt.Errorf("building SPDX document for image %q: %w", image, err)
+		}
+		docs[image] = doc
+	}
+
+	return docs, nil
+}
+
+// scopeImageNames returns the image names a ScopeImage export should
+// produce one document per, or a single empty-string entry for
+// ScopeOrganization.
+func (c *Client) scopeImageNames(ctx context.Context, filter SbomPackagesQuery, scope SbomScope) ([]string, error) {
+	if scope == ScopeOrganization {
+		return []string{filter.ImageName}, nil
+	}
+
+	if filter.ImageName != "" {
+		return []string{filter.ImageName}, nil
+	}
+
+	findings, err := c.GetVulnerabilityFindings(ctx, VulnerabilityFindingsQuery{
+		CloudAccountID: filter.CloudAccountID,
+		Framework:      filter.Framework,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("discovering images: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var images []string
+	for _, finding := range findings {
+		if finding.Image == nil || finding.Image.Name == "" || seen[finding.Image.Name] {
+			continue
+		}
+		seen[finding.Image.Name] = true
+		images = append(images, finding.Image.Name)
+	}
+
+	return images, nil
+}
+
+// vulnerabilityFindingsByPackage fetches every vulnerability finding and
+// indexes it by "name@version" so SBOM export can join findings to
+// packages without an N+1 lookup per package.
+func (c *Client) vulnerabilityFindingsByPackage(ctx context.Context) (map[string][]VulnerabilityFinding, error) {
+	findings, err := c.GetVulnerabilityFindings(ctx, VulnerabilityFindingsQuery{})
+	if err != nil {
+		return nil, err
+	}
+
+	byPackage := make(map[string][]VulnerabilityFinding)
+	for _, finding := range findings {
+		if finding.Package == nil {
+			continue
+		}
+		key := packageKey(finding.Package.Name, finding.Package.Version)
+		byPackage[key] = append(byPackage[key], finding)
+	}
+
+	return byPackage, nil
+}
+
+func packageKey(name, version string) string {
+	return name + "@" + version
+}
+
+func componentRef(pkg SbomPackage) string {
+	return "component-" + packageKey(pkg.Name, pkg.Version)
+}
+
+func spdxPackageID(pkg SbomPackage) string {
+	return "Package-" + sanitizeSPDXID(packageKey(pkg.Name, pkg.Version))
+}
+
+func spdxVulnerabilityID(finding VulnerabilityFinding) string {
+	return "Vulnerability-" + sanitizeSPDXID(vulnerabilityName(finding))
+}
+
+func vulnerabilityName(finding VulnerabilityFinding) string {
+	if finding.Vulnerability == nil {
+		return finding.ID
+	}
+	if finding.Vulnerability.NVDCVEID != "" {
+		return finding.Vulnerability.NVDCVEID
+	}
+	return finding.Vulnerability.Name
+}
+
+// sanitizeSPDXID replaces characters that aren't valid in an SPDX
+// element identifier ([A-Za-z0-9.-]) with hyphens.
+func sanitizeSPDXID(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('-')
+		}
+	}
+	return b.String()
+}
+
+func spdxLicenseExpression(licenses []string) string {
+	if len(licenses) == 0 {
+		return "NOASSERTION"
+	}
+	return strings.Join(licenses, " OR ")
+}
+
+// purlForPackage synthesizes a package URL (https://github.com/package-url/purl-spec)
+// for pkg, mapping Upwind's PackageManager values onto their purl type.
+func purlForPackage(pkg SbomPackage) string {
+	return fmt.Sprintf("pkg:%s/%s@%s", purlType(pkg.PackageManager), pkg.Name, pkg.Version)
+}
+
+func purlType(packageManager string) string {
+	switch strings.ToLower(packageManager) {
+	case "npm":
+		return "npm"
+	case "pip", "pypi":
+		return "pypi"
+	case "go", "golang":
+		return "golang"
+	case "maven":
+		return "maven"
+	case "nuget":
+		return "nuget"
+	case "gem", "rubygems":
+		return "gem"
+	case "composer":
+		return "composer"
+	case "cargo":
+		return "cargo"
+	case "apk":
+		return "apk"
+	case "deb", "dpkg":
+		return "deb"
+	case "rpm":
+		return "rpm"
+	default:
+		return "generic"
+	}
+}
+
+// cyclonedxVulnerability builds the CycloneDX vulnerability entry for
+// finding. It reports ok == false for a finding with no Vulnerability
+// (valid API data - a finding can reference a Package without one),
+// since there's nothing to describe.
+func cyclonedxVulnerability(finding VulnerabilityFinding, componentRef string) (vuln cyclonedx.Vulnerability, ok bool) {
+	if finding.Vulnerability == nil {
+		return cyclonedx.Vulnerability{}, false
+	}
+
+	vuln = cyclonedx.Vulnerability{
+		ID:          vulnerabilityName(finding),
+		Description: finding.Vulnerability.Description,
+		Affects:     &[]cyclonedx.Affects{{Ref: componentRef}},
+	}
+	if finding.Vulnerability.NVDCVSSV3Severity != "" {
+		vuln.Ratings = &[]cyclonedx.VulnerabilityRating{
+			{Severity: cyclonedx.Severity(strings.ToLower(finding.Vulnerability.NVDCVSSV3Severity))},
+		}
+	}
+	return vuln, true
+}
+
+// MarshalCycloneDXJSON writes bom to w as CycloneDX JSON.
+func MarshalCycloneDXJSON(w io.Writer, bom *cyclonedx.BOM) error {
+	return cyclonedx.NewBOMEncoder(w, cyclonedx.BOMFileFormatJSON).Encode(bom)
+}
+
+// MarshalCycloneDXXML writes bom to w as CycloneDX XML.
+func MarshalCycloneDXXML(w io.Writer, bom *cyclonedx.BOM) error {
+	return cyclonedx.NewBOMEncoder(w, cyclonedx.BOMFileFormatXML).Encode(bom)
+}
+
+// MarshalSPDXJSON writes doc to w as SPDX JSON.
+func MarshalSPDXJSON(w io.Writer, doc *spdx.Document) error {
+	return spdxjson.Write(doc, w)
+}
+
+// MarshalSPDXTagValue writes doc to w in SPDX tag-value format.
+func MarshalSPDXTagValue(w io.Writer, doc *spdx.Document) error {
+	return spdxtv.RenderDocument(doc, w)
+}
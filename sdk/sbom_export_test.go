@@ -0,0 +1,145 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package sdk
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	cyclonedx "github.com/CycloneDX/cyclonedx-go"
+	common "github.com/spdx/tools-golang/spdx/v2/common"
+	spdx "github.com/spdx/tools-golang/spdx/v2/v2_3"
+)
+
+func TestCyclonedxVulnerabilitySkipsNilVulnerability(t *testing.T) {
+	_, ok := cyclonedxVulnerability(VulnerabilityFinding{ID: "finding-1"}, "component-1")
+	if ok {
+		t.Error("cyclonedxVulnerability() ok = true for a finding with no Vulnerability, want false")
+	}
+}
+
+func TestCyclonedxVulnerabilityBuildsEntry(t *testing.T) {
+	finding := VulnerabilityFinding{
+		Vulnerability: &Vulnerability{
+			NVDCVEID:          "CVE-2024-0001",
+			Description:       "example vulnerability",
+			NVDCVSSV3Severity: "HIGH",
+		},
+	}
+
+	vuln, ok := cyclonedxVulnerability(finding, "component-1")
+	if !ok {
+		t.Fatal("cyclonedxVulnerability() ok = false, want true")
+	}
+	if vuln.ID != "CVE-2024-0001" {
+		t.Errorf("ID = %q, want CVE-2024-0001", vuln.ID)
+	}
+	if vuln.Description != "example vulnerability" {
+		t.Errorf("Description = %q, want example vulnerability", vuln.Description)
+	}
+	if vuln.Affects == nil || len(*vuln.Affects) != 1 || (*vuln.Affects)[0].Ref != "component-1" {
+		t.Errorf("Affects = %+v, want one entry referencing component-1", vuln.Affects)
+	}
+	if vuln.Ratings == nil || len(*vuln.Ratings) != 1 || (*vuln.Ratings)[0].Severity != "high" {
+		t.Errorf("Ratings = %+v, want one entry with severity high", vuln.Ratings)
+	}
+}
+
+func TestCyclonedxVulnerabilityOmitsRatingsWithoutSeverity(t *testing.T) {
+	finding := VulnerabilityFinding{Vulnerability: &Vulnerability{Name: "example"}}
+
+	vuln, ok := cyclonedxVulnerability(finding, "component-1")
+	if !ok {
+		t.Fatal("cyclonedxVulnerability() ok = false, want true")
+	}
+	if vuln.Ratings != nil {
+		t.Errorf("Ratings = %+v, want nil without an NVDCVSSV3Severity", vuln.Ratings)
+	}
+}
+
+func TestPurlForPackage(t *testing.T) {
+	tests := []struct {
+		packageManager string
+		want           string
+	}{
+		{"npm", "pkg:npm/left-pad@1.0.0"},
+		{"pip", "pkg:pypi/left-pad@1.0.0"},
+		{"golang", "pkg:golang/left-pad@1.0.0"},
+		{"bogus", "pkg:generic/left-pad@1.0.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.packageManager, func(t *testing.T) {
+			pkg := SbomPackage{Name: "left-pad", Version: "1.0.0", PackageManager: tt.packageManager}
+			if got := purlForPackage(pkg); got != tt.want {
+				t.Errorf("purlForPackage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitizeSPDXID(t *testing.T) {
+	if got := sanitizeSPDXID("left-pad@1.0.0+build"); got != "left-pad-1.0.0-build" {
+		t.Errorf("sanitizeSPDXID() = %q, want left-pad-1.0.0-build", got)
+	}
+}
+
+func TestSpdxLicenseExpression(t *testing.T) {
+	if got := spdxLicenseExpression(nil); got != "NOASSERTION" {
+		t.Errorf("spdxLicenseExpression(nil) = %q, want NOASSERTION", got)
+	}
+	if got := spdxLicenseExpression([]string{"MIT", "Apache-2.0"}); got != "MIT OR Apache-2.0" {
+		t.Errorf("spdxLicenseExpression() = %q, want MIT OR Apache-2.0", got)
+	}
+}
+
+func newTestSPDXDocument() *spdx.Document {
+	return &spdx.Document{
+		SPDXVersion:       spdx.Version,
+		DataLicense:       spdx.DataLicense,
+		SPDXIdentifier:    "DOCUMENT",
+		DocumentName:      "test-sbom",
+		DocumentNamespace: "https://upwind.io/spdxdocs/test",
+		CreationInfo: &spdx.CreationInfo{
+			Creators: []common.Creator{{Creator: "Tool: upwind-go-sdk", CreatorType: "Tool"}},
+			Created:  "2024-01-01T00:00:00Z",
+		},
+	}
+}
+
+func TestMarshalCycloneDXJSONRoundTrips(t *testing.T) {
+	bom := cyclonedx.NewBOM()
+	components := []cyclonedx.Component{{BOMRef: "component-1", Type: cyclonedx.ComponentTypeLibrary, Name: "left-pad", Version: "1.0.0"}}
+	bom.Components = &components
+
+	var buf bytes.Buffer
+	if err := MarshalCycloneDXJSON(&buf, bom); err != nil {
+		t.Fatalf("MarshalCycloneDXJSON() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "left-pad") {
+		t.Errorf("MarshalCycloneDXJSON() output = %s, want it to contain left-pad", buf.String())
+	}
+}
+
+func TestMarshalSPDXJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := MarshalSPDXJSON(&buf, newTestSPDXDocument()); err != nil {
+		t.Fatalf("MarshalSPDXJSON() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "test-sbom") {
+		t.Errorf("MarshalSPDXJSON() output = %s, want it to contain test-sbom", buf.String())
+	}
+}
+
+func TestMarshalSPDXTagValue(t *testing.T) {
+	var buf bytes.Buffer
+	if err := MarshalSPDXTagValue(&buf, newTestSPDXDocument()); err != nil {
+		t.Fatalf("MarshalSPDXTagValue() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "test-sbom") {
+		t.Errorf("MarshalSPDXTagValue() output = %s, want it to contain test-sbom", buf.String())
+	}
+}
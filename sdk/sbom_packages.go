@@ -23,41 +23,94 @@ type SbomPackagesQuery struct {
 	PackageLicense string
 }
 
-// ListSbomPackages retrieves all SBOM packages
-func (c *Client) ListSbomPackages(ctx context.Context, query *SbomPackagesQuery) ([]SbomPackage, error) {
-	if query == nil {
-		query = &SbomPackagesQuery{}
+// Encode implements QueryEncoder, building the URL-encoded query string
+// for a single page of SBOM packages.
+func (q SbomPackagesQuery) Encode(pageToken string) string {
+	params := url.Values{}
+
+	if pageToken != "" {
+		params.Add("page-token", pageToken)
+	}
+	if q.CloudAccountID != "" {
+		params.Add("cloud-account-id", q.CloudAccountID)
+	}
+	if q.Framework != "" {
+		params.Add("framework", q.Framework)
+	}
+	if q.ImageName != "" {
+		params.Add("image-name", q.ImageName)
+	}
+	if q.PackageName != "" {
+		params.Add("package-name", q.PackageName)
+	}
+	if q.PackageManager != "" {
+		params.Add("package-manager", q.PackageManager)
+	}
+	if q.PackageLicense != "" {
+		params.Add("package-license", q.PackageLicense)
 	}
 
-	urlPath := fmt.Sprintf("%s/organizations/%s/sbom-packages", c.config.GetBaseURL(), c.config.OrganizationID)
-	queryParams := c.buildSbomPackagesQueryParams(query)
+	return params.Encode()
+}
 
-	if len(queryParams) > 0 {
+// listSbomPackagesPage retrieves a single page of SBOM packages.
+func (c *Client) listSbomPackagesPage(ctx context.Context, query SbomPackagesQuery, pageToken string, header *http.Header) ([]SbomPackage, string, error) {
+	urlPath := fmt.Sprintf("%s/organizations/%s/sbom-packages", c.config.GetBaseURL(), c.config.OrganizationID)
+	if queryParams := query.Encode(pageToken); queryParams != "" {
 		urlPath += "?" + queryParams
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "GET", urlPath, nil)
 	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
+		return nil, "", fmt.Errorf("creating request: %w", err)
 	}
 
 	resp, err := c.doRequest(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("executing request: %w", err)
+		return nil, "", fmt.Errorf("executing request: %w", err)
 	}
 	defer resp.Body.Close()
 
+	if header != nil {
+		*header = resp.Header
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+		return nil, "", decodeError(resp, body)
 	}
 
 	var packages []SbomPackage
 	if err := json.NewDecoder(resp.Body).Decode(&packages); err != nil {
-		return nil, fmt.Errorf("decoding response: %w", err)
+		return nil, "", fmt.Errorf("decoding response: %w", err)
+	}
+
+	nextToken, err := extractNextLink(resp.Header.Get("Link"))
+	if err != nil {
+		return nil, "", fmt.Errorf("parsing pagination link: %w", err)
 	}
 
-	return packages, nil
+	return packages, nextToken, nil
+}
+
+// ListSbomPackagesPaginator returns a Paginator over SBOM packages
+// matching query, fetching pages on demand instead of loading the full
+// result set into memory.
+func (c *Client) ListSbomPackagesPaginator(query SbomPackagesQuery) *Paginator[SbomPackage] {
+	return NewPaginator(func(ctx context.Context, pageToken string, header *http.Header) ([]SbomPackage, string, error) {
+		return c.listSbomPackagesPage(ctx, query, pageToken, header)
+	})
+}
+
+// ListSbomPackages retrieves all SBOM packages matching query, following
+// pagination until exhausted. It is a thin backward-compatible wrapper
+// around ListSbomPackagesPaginator; prefer that method directly for
+// large result sets.
+func (c *Client) ListSbomPackages(ctx context.Context, query *SbomPackagesQuery) ([]SbomPackage, error) {
+	if query == nil {
+		query = &SbomPackagesQuery{}
+	}
+	return c.ListSbomPackagesPaginator(*query).All(ctx)
 }
 
 // GetSbomPackageDetails retrieves detailed information about a specific SBOM package
@@ -76,13 +129,9 @@ func (c *Client) GetSbomPackageDetails(ctx context.Context, packageName, version
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("SBOM package not found: %s@%s", packageName, version)
-	}
-
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+		return nil, decodeError(resp, body)
 	}
 
 	var pkg SbomPackage
@@ -92,29 +141,3 @@ func (c *Client) GetSbomPackageDetails(ctx context.Context, packageName, version
 
 	return &pkg, nil
 }
-
-// buildSbomPackagesQueryParams constructs URL query parameters for SBOM packages
-func (c *Client) buildSbomPackagesQueryParams(query *SbomPackagesQuery) string {
-	params := url.Values{}
-
-	if query.CloudAccountID != "" {
-		params.Add("cloud-account-id", query.CloudAccountID)
-	}
-	if query.Framework != "" {
-		params.Add("framework", query.Framework)
-	}
-	if query.ImageName != "" {
-		params.Add("image-name", query.ImageName)
-	}
-	if query.PackageName != "" {
-		params.Add("package-name", query.PackageName)
-	}
-	if query.PackageManager != "" {
-		params.Add("package-manager", query.PackageManager)
-	}
-	if query.PackageLicense != "" {
-		params.Add("package-license", query.PackageLicense)
-	}
-
-	return params.Encode()
-}
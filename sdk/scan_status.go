@@ -0,0 +1,246 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// ScanType identifies one of the scan categories reported by GetScanStatus.
+type ScanType string
+
+const (
+	ScanVulnerability   ScanType = "vulnerability"
+	ScanCompliance      ScanType = "compliance"
+	ScanSecret          ScanType = "secret"
+	ScanMalware         ScanType = "malware"
+	ScanCloudCompliance ScanType = "cloud_compliance"
+	ScanThreat          ScanType = "threat"
+)
+
+// allScanTypes is used when ScanStatusQuery.Types is empty.
+var allScanTypes = []ScanType{
+	ScanVulnerability,
+	ScanCompliance,
+	ScanSecret,
+	ScanMalware,
+	ScanCloudCompliance,
+	ScanThreat,
+}
+
+// Scan status values reported in ScanSummary.Status.
+const (
+	// ScanStatusOK means the scan ran and returned no open findings.
+	ScanStatusOK = "ok"
+	// ScanStatusFindings means the scan ran and returned at least one
+	// open finding.
+	ScanStatusFindings = "findings"
+	// ScanStatusUnsupported means this module has no data source for
+	// the scan type, so the summary is a placeholder.
+	ScanStatusUnsupported = "unsupported"
+)
+
+// ScanSummary is a client-assembled summary of the current posture for a
+// single ScanType.
+type ScanSummary struct {
+	Type           ScanType
+	Status         string
+	LatestScanID   string
+	LatestScanTime string
+	Count          int
+	BySeverity     map[string]int
+}
+
+// ScanStatusQuery scopes GetScanStatus. Types defaults to every ScanType
+// when left empty. CloudAccountID, ClusterID, and Namespace are applied
+// to each fanned-out query that supports the corresponding filter; scan
+// types whose underlying query has no such filter are summarized
+// org-wide regardless of these fields.
+type ScanStatusQuery struct {
+	CloudAccountID string
+	ClusterID      string
+	Namespace      string
+	Types          []ScanType
+}
+
+// ScanStatusField, LatestScanIDField, and ScanCountField read the
+// corresponding field off every summary in a GetScanStatus result,
+// keyed by ScanType, so dashboards can render any scan type generically
+// without a type switch.
+func ScanStatusField(summaries []ScanSummary) map[ScanType]string {
+	fields := make(map[ScanType]string, len(summaries))
+	for _, s := range summaries {
+		fields[s.Type] = s.Status
+	}
+	return fields
+}
+
+func LatestScanIDField(summaries []ScanSummary) map[ScanType]string {
+	fields := make(map[ScanType]string, len(summaries))
+	for _, s := range summaries {
+		fields[s.Type] = s.LatestScanID
+	}
+	return fields
+}
+
+func ScanCountField(summaries []ScanSummary) map[ScanType]int {
+	fields := make(map[ScanType]int, len(summaries))
+	for _, s := range summaries {
+		fields[s.Type] = s.Count
+	}
+	return fields
+}
+
+// GetScanStatus assembles a ScanSummary per requested ScanType. The
+// module has no native aggregate-status endpoint, so each scan type is
+// backed by a fan-out to the equivalent findings/detections query,
+// bounded by Config.MaxConcurrency via errgroup.
+func (c *Client) GetScanStatus(ctx context.Context, query ScanStatusQuery) ([]ScanSummary, error) {
+	types := query.Types
+	if len(types) == 0 {
+		types = allScanTypes
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(c.config.MaxConcurrency)
+
+	summaries := make([]ScanSummary, len(types))
+	for i, scanType := range types {
+		i, scanType := i, scanType
+		g.Go(func() error {
+			summary, err := c.scanSummaryFor(ctx, scanType, query)
+			if err != nil {
+				return fmt.Errorf("getting %s scan status: %w", scanType, err)
+			}
+			summaries[i] = summary
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return summaries, nil
+}
+
+// scanSummaryFor dispatches to the data source backing scanType.
+func (c *Client) scanSummaryFor(ctx context.Context, scanType ScanType, query ScanStatusQuery) (ScanSummary, error) {
+	switch scanType {
+	case ScanVulnerability:
+		return c.vulnerabilityScanSummary(ctx, query)
+	case ScanCompliance, ScanCloudCompliance:
+		return c.configurationScanSummary(ctx, scanType)
+	case ScanThreat:
+		return c.threatScanSummary(ctx)
+	default:
+		// No Upwind endpoint backs this scan type yet; report it as
+		// unsupported rather than guessing at a count.
+		return ScanSummary{Type: scanType, Status: ScanStatusUnsupported}, nil
+	}
+}
+
+func (c *Client) vulnerabilityScanSummary(ctx context.Context, query ScanStatusQuery) (ScanSummary, error) {
+	findings, err := c.GetVulnerabilityFindings(ctx, VulnerabilityFindingsQuery{
+		CloudAccountID: query.CloudAccountID,
+		ClusterID:      query.ClusterID,
+		Namespace:      query.Namespace,
+	})
+	if err != nil {
+		return ScanSummary{}, err
+	}
+
+	summary := ScanSummary{Type: ScanVulnerability, BySeverity: map[string]int{}}
+	for _, finding := range findings {
+		summary.Count++
+		summary.BySeverity[vulnerabilitySeverity(finding)]++
+		if finding.LastScanTime > summary.LatestScanTime {
+			summary.LatestScanTime = finding.LastScanTime
+		}
+	}
+	summary.Status = statusFromCount(summary.Count)
+
+	return summary, nil
+}
+
+// vulnerabilitySeverity picks the highest CVSS version severity present
+// on the finding, falling back to "unknown".
+func vulnerabilitySeverity(finding VulnerabilityFinding) string {
+	if finding.Vulnerability == nil {
+		return "unknown"
+	}
+	switch {
+	case finding.Vulnerability.NVDCVSSV4Severity != "":
+		return finding.Vulnerability.NVDCVSSV4Severity
+	case finding.Vulnerability.NVDCVSSV3Severity != "":
+		return finding.Vulnerability.NVDCVSSV3Severity
+	case finding.Vulnerability.NVDCVSSV2Severity != "":
+		return finding.Vulnerability.NVDCVSSV2Severity
+	default:
+		return "unknown"
+	}
+}
+
+// configurationScanSummary backs both ScanCompliance and
+// ScanCloudCompliance: this module has no field distinguishing
+// cloud-resource checks from other configuration checks, so both scan
+// types summarize the same ConfigurationFinding stream.
+func (c *Client) configurationScanSummary(ctx context.Context, scanType ScanType) (ScanSummary, error) {
+	it := c.ListConfigurationFindingsIter(ctx, nil, &ConfigurationFindingsQuery{})
+	defer it.Close()
+
+	summary := ScanSummary{Type: scanType, BySeverity: map[string]int{}}
+	for it.Next() {
+		finding := it.Value()
+		summary.Count++
+		severity := finding.Severity
+		if severity == "" {
+			severity = "unknown"
+		}
+		summary.BySeverity[severity]++
+		if finding.LastSeenTime > summary.LatestScanTime {
+			summary.LatestScanTime = finding.LastSeenTime
+		}
+	}
+	if err := it.Err(); err != nil {
+		return ScanSummary{}, err
+	}
+	summary.Status = statusFromCount(summary.Count)
+
+	return summary, nil
+}
+
+func (c *Client) threatScanSummary(ctx context.Context) (ScanSummary, error) {
+	detections, err := c.ListThreatDetections(ctx, &ThreatDetectionsQuery{})
+	if err != nil {
+		return ScanSummary{}, err
+	}
+
+	summary := ScanSummary{Type: ScanThreat, BySeverity: map[string]int{}}
+	for _, detection := range detections {
+		summary.Count++
+		severity := detection.Severity
+		if severity == "" {
+			severity = "unknown"
+		}
+		summary.BySeverity[severity]++
+		if detection.LastSeenTime > summary.LatestScanTime {
+			summary.LatestScanTime = detection.LastSeenTime
+		}
+	}
+	summary.Status = statusFromCount(summary.Count)
+
+	return summary, nil
+}
+
+func statusFromCount(count int) string {
+	if count == 0 {
+		return ScanStatusOK
+	}
+	return ScanStatusFindings
+}
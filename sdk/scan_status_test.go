@@ -0,0 +1,57 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package sdk
+
+import "testing"
+
+func TestVulnerabilitySeverityPrefersHighestCVSSVersion(t *testing.T) {
+	finding := VulnerabilityFinding{
+		Vulnerability: &Vulnerability{
+			NVDCVSSV2Severity: "medium",
+			NVDCVSSV3Severity: "high",
+		},
+	}
+
+	if got := vulnerabilitySeverity(finding); got != "high" {
+		t.Errorf("vulnerabilitySeverity() = %q, want %q", got, "high")
+	}
+}
+
+func TestVulnerabilitySeverityUnknownWithoutVulnerability(t *testing.T) {
+	if got := vulnerabilitySeverity(VulnerabilityFinding{}); got != "unknown" {
+		t.Errorf("vulnerabilitySeverity() = %q, want %q", got, "unknown")
+	}
+}
+
+func TestStatusFromCount(t *testing.T) {
+	if got := statusFromCount(0); got != ScanStatusOK {
+		t.Errorf("statusFromCount(0) = %q, want %q", got, ScanStatusOK)
+	}
+	if got := statusFromCount(3); got != ScanStatusFindings {
+		t.Errorf("statusFromCount(3) = %q, want %q", got, ScanStatusFindings)
+	}
+}
+
+func TestScanStatusFieldMaps(t *testing.T) {
+	summaries := []ScanSummary{
+		{Type: ScanVulnerability, Status: ScanStatusFindings, LatestScanID: "scan-1", Count: 4},
+		{Type: ScanThreat, Status: ScanStatusOK, LatestScanID: "scan-2", Count: 0},
+	}
+
+	statuses := ScanStatusField(summaries)
+	if statuses[ScanVulnerability] != ScanStatusFindings || statuses[ScanThreat] != ScanStatusOK {
+		t.Errorf("ScanStatusField() = %v", statuses)
+	}
+
+	ids := LatestScanIDField(summaries)
+	if ids[ScanVulnerability] != "scan-1" || ids[ScanThreat] != "scan-2" {
+		t.Errorf("LatestScanIDField() = %v", ids)
+	}
+
+	counts := ScanCountField(summaries)
+	if counts[ScanVulnerability] != 4 || counts[ScanThreat] != 0 {
+		t.Errorf("ScanCountField() = %v", counts)
+	}
+}
@@ -0,0 +1,85 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package sdk
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/tektite-io/upwind-go-sdk/sdk"
+
+// TracingMiddleware returns a Middleware that wraps each request in an
+// OpenTelemetry span, using the tracer provider configured globally via
+// otel.SetTracerProvider. Use WithMiddleware(sdk.TracingMiddleware()) to
+// enable it.
+func TracingMiddleware() Middleware {
+	tracer := otel.Tracer(tracerName)
+
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			ctx, span := tracer.Start(req.Context(), req.Method+" "+req.URL.Path,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(
+					attribute.String("http.method", req.Method),
+					attribute.String("http.url", req.URL.String()),
+				),
+			)
+			defer span.End()
+
+			resp, err := next(req.Clone(ctx))
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				return nil, err
+			}
+
+			span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode))
+			if resp.StatusCode >= 400 {
+				span.SetStatus(codes.Error, resp.Status)
+			}
+
+			return resp, nil
+		}
+	}
+}
+
+// SlogMiddleware returns a Middleware that logs each request and its
+// outcome via the standard library's structured logger. Use
+// WithMiddleware(sdk.SlogMiddleware(logger)) to enable it.
+func SlogMiddleware(logger *slog.Logger) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next(req)
+			duration := time.Since(start)
+
+			if err != nil {
+				logger.Error("request failed",
+					"method", req.Method,
+					"url", req.URL.String(),
+					"duration", duration,
+					"error", err,
+				)
+				return nil, err
+			}
+
+			logger.Info("request completed",
+				"method", req.Method,
+				"url", req.URL.String(),
+				"duration", duration,
+				"status", resp.StatusCode,
+			)
+
+			return resp, nil
+		}
+	}
+}
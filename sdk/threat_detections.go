@@ -12,13 +12,38 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
+	"time"
 )
 
+// Validate checks that query's Severity, if set, is a recognized
+// severity, and that SortBy names a sort field for every entry.
+func (q *ThreatDetectionsQuery) Validate() error {
+	if q.Severity != "" {
+		switch q.Severity {
+		case SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical:
+		default:
+			return fmt.Errorf("invalid severity: %s", q.Severity)
+		}
+	}
+	return validateSortBy(q.SortBy)
+}
+
 // ListThreatDetections retrieves all threat detections (no pagination for this endpoint)
 func (c *Client) ListThreatDetections(ctx context.Context, query *ThreatDetectionsQuery) ([]ThreatDetection, error) {
 	if query == nil {
 		query = &ThreatDetectionsQuery{}
 	}
+	return c.listThreatDetectionsPage(ctx, query, nil)
+}
+
+// listThreatDetectionsPage retrieves all threat detections matching
+// query. It writes the response headers to header when non-nil, so
+// IterateThreatDetections can thread them through Paginator.
+func (c *Client) listThreatDetectionsPage(ctx context.Context, query *ThreatDetectionsQuery, header *http.Header) ([]ThreatDetection, error) {
+	if err := query.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid query: %w", err)
+	}
 
 	urlPath := fmt.Sprintf("%s/organizations/%s/threat-detections", c.config.GetBaseURL(), c.config.OrganizationID)
 	queryParams := c.buildThreatDetectionsQueryParams(query)
@@ -38,9 +63,13 @@ func (c *Client) ListThreatDetections(ctx context.Context, query *ThreatDetectio
 	}
 	defer resp.Body.Close()
 
+	if header != nil {
+		*header = resp.Header
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+		return nil, decodeError(resp, body)
 	}
 
 	var detections []ThreatDetection
@@ -67,13 +96,9 @@ func (c *Client) GetThreatDetection(ctx context.Context, detectionID string) (*T
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("threat detection not found: %s", detectionID)
-	}
-
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+		return nil, decodeError(resp, body)
 	}
 
 	var detection ThreatDetection
@@ -84,23 +109,56 @@ func (c *Client) GetThreatDetection(ctx context.Context, detectionID string) (*T
 	return &detection, nil
 }
 
-// UpdateThreatDetection updates a threat detection (e.g., to archive it)
+// UpdateThreatDetection updates a threat detection (e.g., to archive it).
+//
+// Deprecated: use UpdateThreatDetectionTyped with an
+// UpdateThreatDetectionRequest for compile-time field checking.
+// UpdateThreatDetection round-trips update through JSON to build the
+// typed request, so a misspelled key fails at request time instead of
+// at compile time.
 func (c *Client) UpdateThreatDetection(ctx context.Context, detectionID string, update map[string]interface{}) (*ThreatDetection, error) {
+	var req UpdateThreatDetectionRequest
+	if err := remarshal(update, &req); err != nil {
+		return nil, err
+	}
+	return c.UpdateThreatDetectionTyped(ctx, detectionID, &req)
+}
+
+// Validate checks that req's Status, if set, is a recognized threat
+// detection status.
+func (req *UpdateThreatDetectionRequest) Validate() error {
+	if req.Status != nil {
+		switch *req.Status {
+		case StatusOpen, StatusPending, StatusArchived:
+		default:
+			return fmt.Errorf("invalid status: %s", *req.Status)
+		}
+	}
+	return nil
+}
+
+// UpdateThreatDetectionTyped updates a threat detection from a typed
+// request.
+func (c *Client) UpdateThreatDetectionTyped(ctx context.Context, detectionID string, req *UpdateThreatDetectionRequest) (*ThreatDetection, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
 	urlPath := fmt.Sprintf("%s/organizations/%s/threat-detections/%s",
 		c.config.GetBaseURL(), c.config.OrganizationID, detectionID)
 
-	body, err := json.Marshal(update)
+	body, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("marshaling request: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "PATCH", urlPath, bytes.NewReader(body))
+	httpReq, err := http.NewRequestWithContext(ctx, "PATCH", urlPath, bytes.NewReader(body))
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %w", err)
 	}
-	req.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.doRequest(ctx, req)
+	resp, err := c.doRequest(ctx, httpReq)
 	if err != nil {
 		return nil, fmt.Errorf("executing request: %w", err)
 	}
@@ -112,7 +170,7 @@ func (c *Client) UpdateThreatDetection(ctx context.Context, detectionID string,
 
 	if resp.StatusCode != http.StatusOK {
 		respBody, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(respBody))
+		return nil, decodeError(resp, respBody)
 	}
 
 	var detection ThreatDetection
@@ -125,9 +183,21 @@ func (c *Client) UpdateThreatDetection(ctx context.Context, detectionID string,
 
 // ArchiveThreatDetection archives a threat detection
 func (c *Client) ArchiveThreatDetection(ctx context.Context, detectionID string) (*ThreatDetection, error) {
-	return c.UpdateThreatDetection(ctx, detectionID, map[string]interface{}{
-		"status": "ARCHIVED",
-	})
+	status := StatusArchived
+	return c.UpdateThreatDetectionTyped(ctx, detectionID, &UpdateThreatDetectionRequest{Status: &status})
+}
+
+// Validate checks that query's Severity, if set, is a recognized
+// severity, and that SortBy names a sort field for every entry.
+func (q *ThreatEventsQuery) Validate() error {
+	if q.Severity != "" {
+		switch q.Severity {
+		case SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical:
+		default:
+			return fmt.Errorf("invalid severity: %s", q.Severity)
+		}
+	}
+	return validateSortBy(q.SortBy)
 }
 
 // ListThreatEvents retrieves threat events with page-based pagination
@@ -149,7 +219,7 @@ func (c *Client) ListThreatEvents(ctx context.Context, query *ThreatEventsQuery)
 	page := query.Page
 	for {
 		query.Page = page
-		events, hasMore, err := c.listThreatEventsPage(ctx, query)
+		events, hasMore, err := c.listThreatEventsPage(ctx, query, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -165,8 +235,14 @@ func (c *Client) ListThreatEvents(ctx context.Context, query *ThreatEventsQuery)
 	return allEvents, nil
 }
 
-// listThreatEventsPage retrieves a single page of threat events
-func (c *Client) listThreatEventsPage(ctx context.Context, query *ThreatEventsQuery) ([]ThreatEvent, bool, error) {
+// listThreatEventsPage retrieves a single page of threat events. It
+// writes the response headers to header when non-nil, so
+// IterateThreatEvents can thread them through Paginator.
+func (c *Client) listThreatEventsPage(ctx context.Context, query *ThreatEventsQuery, header *http.Header) ([]ThreatEvent, bool, error) {
+	if err := query.Validate(); err != nil {
+		return nil, false, fmt.Errorf("invalid query: %w", err)
+	}
+
 	urlPath := fmt.Sprintf("%s/organizations/%s/threat-events", c.config.GetBaseURL(), c.config.OrganizationID)
 	queryParams := c.buildThreatEventsQueryParams(query)
 
@@ -185,9 +261,13 @@ func (c *Client) listThreatEventsPage(ctx context.Context, query *ThreatEventsQu
 	}
 	defer resp.Body.Close()
 
+	if header != nil {
+		*header = resp.Header
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, false, fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+		return nil, false, decodeError(resp, body)
 	}
 
 	var events []ThreatEvent
@@ -222,7 +302,7 @@ func (c *Client) ListThreatPolicies(ctx context.Context, managedBy string) ([]Th
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+		return nil, decodeError(resp, body)
 	}
 
 	var policies []ThreatPolicy
@@ -233,23 +313,51 @@ func (c *Client) ListThreatPolicies(ctx context.Context, managedBy string) ([]Th
 	return policies, nil
 }
 
-// UpdateThreatPolicy updates a threat policy (e.g., to enable/disable it)
+// UpdateThreatPolicy updates a threat policy (e.g., to enable/disable it).
+//
+// Deprecated: use UpdateThreatPolicyTyped with an
+// UpdateThreatPolicyRequest for compile-time field checking.
+// UpdateThreatPolicy round-trips update through JSON to build the typed
+// request, so a misspelled key fails at request time instead of at
+// compile time.
 func (c *Client) UpdateThreatPolicy(ctx context.Context, policyID string, update map[string]interface{}) (*ThreatPolicy, error) {
+	var req UpdateThreatPolicyRequest
+	if err := remarshal(update, &req); err != nil {
+		return nil, err
+	}
+	return c.UpdateThreatPolicyTyped(ctx, policyID, &req)
+}
+
+// Validate checks that req has the fields required to update a threat
+// policy.
+func (req *UpdateThreatPolicyRequest) Validate() error {
+	if req.Enabled == nil {
+		return fmt.Errorf("enabled is required")
+	}
+	return nil
+}
+
+// UpdateThreatPolicyTyped updates a threat policy from a typed request.
+func (c *Client) UpdateThreatPolicyTyped(ctx context.Context, policyID string, req *UpdateThreatPolicyRequest) (*ThreatPolicy, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
 	urlPath := fmt.Sprintf("%s/organizations/%s/threat-policies/%s",
 		c.config.GetBaseURL(), c.config.OrganizationID, policyID)
 
-	body, err := json.Marshal(update)
+	body, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("marshaling request: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "PATCH", urlPath, bytes.NewReader(body))
+	httpReq, err := http.NewRequestWithContext(ctx, "PATCH", urlPath, bytes.NewReader(body))
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %w", err)
 	}
-	req.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.doRequest(ctx, req)
+	resp, err := c.doRequest(ctx, httpReq)
 	if err != nil {
 		return nil, fmt.Errorf("executing request: %w", err)
 	}
@@ -261,7 +369,7 @@ func (c *Client) UpdateThreatPolicy(ctx context.Context, policyID string, update
 
 	if resp.StatusCode != http.StatusOK {
 		respBody, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(respBody))
+		return nil, decodeError(resp, respBody)
 	}
 
 	var policy ThreatPolicy
@@ -272,6 +380,50 @@ func (c *Client) UpdateThreatPolicy(ctx context.Context, policyID string, update
 	return &policy, nil
 }
 
+// addSeenTimeParams adds the min/max first/last-seen-time params to
+// params, preferring the time.Time field over its string equivalent when
+// both are set.
+func addSeenTimeParams(params url.Values, minFirstSeenTime, maxFirstSeenTime, minLastSeenTime, maxLastSeenTime string, minFirstSeen, maxFirstSeen, minLastSeen, maxLastSeen *time.Time) {
+	switch {
+	case minFirstSeen != nil:
+		params.Add("min-first-seen-time", minFirstSeen.Format(time.RFC3339))
+	case minFirstSeenTime != "":
+		params.Add("min-first-seen-time", minFirstSeenTime)
+	}
+	switch {
+	case maxFirstSeen != nil:
+		params.Add("max-first-seen-time", maxFirstSeen.Format(time.RFC3339))
+	case maxFirstSeenTime != "":
+		params.Add("max-first-seen-time", maxFirstSeenTime)
+	}
+	switch {
+	case minLastSeen != nil:
+		params.Add("min-last-seen-time", minLastSeen.Format(time.RFC3339))
+	case minLastSeenTime != "":
+		params.Add("min-last-seen-time", minLastSeenTime)
+	}
+	switch {
+	case maxLastSeen != nil:
+		params.Add("max-last-seen-time", maxLastSeen.Format(time.RFC3339))
+	case maxLastSeenTime != "":
+		params.Add("max-last-seen-time", maxLastSeenTime)
+	}
+}
+
+// addFilterSortFieldParams adds the filter, sort, and sparse-fieldset
+// params shared by the threat detections and threat events queries.
+func addFilterSortFieldParams(params url.Values, filter *Filter, sortBy []SortField, fields []string) {
+	if filter != nil {
+		params.Add("filter", filter.String())
+	}
+	if sortBy := encodeSortBy(sortBy); sortBy != "" {
+		params.Add("sort-by", sortBy)
+	}
+	if len(fields) > 0 {
+		params.Add("fields", strings.Join(fields, ","))
+	}
+}
+
 // buildThreatDetectionsQueryParams constructs URL query parameters for threat detections
 func (c *Client) buildThreatDetectionsQueryParams(query *ThreatDetectionsQuery) string {
 	params := url.Values{}
@@ -285,18 +437,9 @@ func (c *Client) buildThreatDetectionsQueryParams(query *ThreatDetectionsQuery)
 	if query.Category != "" {
 		params.Add("category", query.Category)
 	}
-	if query.MinFirstSeenTime != "" {
-		params.Add("min-first-seen-time", query.MinFirstSeenTime)
-	}
-	if query.MaxFirstSeenTime != "" {
-		params.Add("max-first-seen-time", query.MaxFirstSeenTime)
-	}
-	if query.MinLastSeenTime != "" {
-		params.Add("min-last-seen-time", query.MinLastSeenTime)
-	}
-	if query.MaxLastSeenTime != "" {
-		params.Add("max-last-seen-time", query.MaxLastSeenTime)
-	}
+	addSeenTimeParams(params, query.MinFirstSeenTime, query.MaxFirstSeenTime, query.MinLastSeenTime, query.MaxLastSeenTime,
+		query.MinFirstSeen, query.MaxFirstSeen, query.MinLastSeen, query.MaxLastSeen)
+	addFilterSortFieldParams(params, query.Filter, query.SortBy, query.Fields)
 
 	return params.Encode()
 }
@@ -314,18 +457,9 @@ func (c *Client) buildThreatEventsQueryParams(query *ThreatEventsQuery) string {
 	if query.Category != "" {
 		params.Add("category", query.Category)
 	}
-	if query.MinFirstSeenTime != "" {
-		params.Add("min-first-seen-time", query.MinFirstSeenTime)
-	}
-	if query.MaxFirstSeenTime != "" {
-		params.Add("max-first-seen-time", query.MaxFirstSeenTime)
-	}
-	if query.MinLastSeenTime != "" {
-		params.Add("min-last-seen-time", query.MinLastSeenTime)
-	}
-	if query.MaxLastSeenTime != "" {
-		params.Add("max-last-seen-time", query.MaxLastSeenTime)
-	}
+	addSeenTimeParams(params, query.MinFirstSeenTime, query.MaxFirstSeenTime, query.MinLastSeenTime, query.MaxLastSeenTime,
+		query.MinFirstSeen, query.MaxFirstSeen, query.MinLastSeen, query.MaxLastSeen)
+	addFilterSortFieldParams(params, query.Filter, query.SortBy, query.Fields)
 	if query.Page > 0 {
 		params.Add("page", fmt.Sprintf("%d", query.Page))
 	}
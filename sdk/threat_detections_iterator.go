@@ -0,0 +1,116 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package sdk
+
+import (
+	"context"
+	"net/http"
+)
+
+// ThreatDetectionIterator streams threat detections, fetching lazily
+// instead of buffering the full result set the way ListThreatDetections
+// does. The underlying endpoint has no server-side pagination, so
+// iteration fetches a single page on the first call to Next and then
+// drains it; the cost savings over ListThreatDetections is in honoring
+// ctx cancellation around the fetch and letting the caller stop
+// draining early. See ThreatEventIterator for the shape this mirrors.
+type ThreatDetectionIterator struct {
+	eventsCh <-chan ThreatDetection
+	errCh    <-chan error
+	cancel   context.CancelFunc
+
+	current ThreatDetection
+	err     error
+	closed  bool
+}
+
+// IterateThreatDetections returns a ThreatDetectionIterator over threat
+// detections matching query. No request is made until the first call to
+// Next, Detections, or Errors.
+func (c *Client) IterateThreatDetections(ctx context.Context, query *ThreatDetectionsQuery) *ThreatDetectionIterator {
+	if query == nil {
+		query = &ThreatDetectionsQuery{}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	paginator := NewPaginator(func(ctx context.Context, pageToken string, header *http.Header) ([]ThreatDetection, string, error) {
+		detections, err := c.listThreatDetectionsPage(ctx, query, header)
+		if err != nil {
+			return nil, "", err
+		}
+		return detections, "", nil
+	})
+	detectionsCh, errCh := paginator.Stream(ctx)
+
+	return &ThreatDetectionIterator{
+		eventsCh: detectionsCh,
+		errCh:    errCh,
+		cancel:   cancel,
+	}
+}
+
+// Next advances the iterator, blocking until the next detection is
+// available, the underlying fetch fails, or ctx is canceled. It returns
+// false when iteration is complete; check Err to distinguish a clean
+// end from a failure.
+func (it *ThreatDetectionIterator) Next() bool {
+	if it.closed {
+		return false
+	}
+
+	select {
+	case detection, ok := <-it.eventsCh:
+		if !ok {
+			select {
+			case err := <-it.errCh:
+				it.err = err
+			default:
+			}
+			return false
+		}
+		it.current = detection
+		return true
+	case err := <-it.errCh:
+		if err != nil {
+			it.err = err
+		}
+		return false
+	}
+}
+
+// Detection returns the detection at the iterator's current position.
+// It is only valid after a call to Next that returned true.
+func (it *ThreatDetectionIterator) Detection() ThreatDetection {
+	return it.current
+}
+
+// Err returns the first error encountered during iteration, if any.
+func (it *ThreatDetectionIterator) Err() error {
+	return it.err
+}
+
+// Detections exposes the iterator's underlying item channel, for
+// callers that prefer the SDK's channel-based convention, e.g.
+// CollectAll(ctx, iter.Detections(), iter.Errors()).
+func (it *ThreatDetectionIterator) Detections() <-chan ThreatDetection {
+	return it.eventsCh
+}
+
+// Errors exposes the iterator's underlying error channel; see Detections.
+func (it *ThreatDetectionIterator) Errors() <-chan error {
+	return it.errCh
+}
+
+// Close stops the iterator's background fetch. It is safe to call
+// multiple times and never returns an error; the method exists so
+// ThreatDetectionIterator satisfies the same shape as io.Closer for
+// callers that defer it unconditionally.
+func (it *ThreatDetectionIterator) Close() error {
+	if !it.closed {
+		it.closed = true
+		it.cancel()
+	}
+	return nil
+}
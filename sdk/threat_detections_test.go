@@ -0,0 +1,132 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package sdk
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestThreatDetectionsQueryValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   ThreatDetectionsQuery
+		wantErr bool
+	}{
+		{"empty", ThreatDetectionsQuery{}, false},
+		{"valid severity", ThreatDetectionsQuery{Severity: SeverityHigh}, false},
+		{"invalid severity", ThreatDetectionsQuery{Severity: "BOGUS"}, true},
+		{"invalid sort field", ThreatDetectionsQuery{SortBy: []SortField{{Direction: SortAscending}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.query.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestThreatEventsQueryValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   ThreatEventsQuery
+		wantErr bool
+	}{
+		{"empty", ThreatEventsQuery{}, false},
+		{"valid severity", ThreatEventsQuery{Severity: SeverityCritical}, false},
+		{"invalid severity", ThreatEventsQuery{Severity: "BOGUS"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.query.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuildThreatDetectionsQueryParams(t *testing.T) {
+	client := newTestClient(t, &Config{
+		ClientID:       "test-client",
+		ClientSecret:   "test-secret",
+		OrganizationID: "test-org",
+		Region:         RegionUS,
+		MaxRetries:     3,
+		MaxConcurrency: 10,
+		PageSize:       100,
+	})
+
+	minFirstSeen := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	query := &ThreatDetectionsQuery{
+		Severity:         SeverityHigh,
+		MinFirstSeenTime: "ignored-because-time-field-set",
+		MinFirstSeen:     &minFirstSeen,
+		Filter:           Eq("category", "malware"),
+		SortBy:           []SortField{{Field: "first_seen_time", Direction: SortDescending}},
+		Fields:           []string{"id", "severity"},
+	}
+
+	params, err := url.ParseQuery(client.buildThreatDetectionsQueryParams(query))
+	if err != nil {
+		t.Fatalf("ParseQuery() error = %v", err)
+	}
+
+	if got := params.Get("severity"); got != "HIGH" {
+		t.Errorf("severity = %q, want HIGH", got)
+	}
+	if got := params.Get("min-first-seen-time"); got != "2024-01-01T00:00:00Z" {
+		t.Errorf("min-first-seen-time = %q, want RFC3339 time, not the string field", got)
+	}
+	if got := params.Get("filter"); got != "category:eq:malware" {
+		t.Errorf("filter = %q, want category:eq:malware", got)
+	}
+	if got := params.Get("sort-by"); got != "first_seen_time:desc" {
+		t.Errorf("sort-by = %q, want first_seen_time:desc", got)
+	}
+	if got := params.Get("fields"); got != "id,severity" {
+		t.Errorf("fields = %q, want id,severity", got)
+	}
+}
+
+func TestBuildThreatEventsQueryParams(t *testing.T) {
+	client := newTestClient(t, &Config{
+		ClientID:       "test-client",
+		ClientSecret:   "test-secret",
+		OrganizationID: "test-org",
+		Region:         RegionUS,
+		MaxRetries:     3,
+		MaxConcurrency: 10,
+		PageSize:       100,
+	})
+
+	query := &ThreatEventsQuery{
+		CloudAccountID: "acct-1",
+		Filter:         And(Eq("severity", SeverityCritical), Not(Eq("category", "noise"))),
+		Page:           2,
+		PerPage:        50,
+	}
+
+	params, err := url.ParseQuery(client.buildThreatEventsQueryParams(query))
+	if err != nil {
+		t.Fatalf("ParseQuery() error = %v", err)
+	}
+
+	if got := params.Get("cloud-account-id"); got != "acct-1" {
+		t.Errorf("cloud-account-id = %q, want acct-1", got)
+	}
+	if got := params.Get("filter"); got != "and(severity:eq:CRITICAL,not(category:eq:noise))" {
+		t.Errorf("filter = %q, want and(severity:eq:CRITICAL,not(category:eq:noise))", got)
+	}
+	if got := params.Get("page"); got != "2" {
+		t.Errorf("page = %q, want 2", got)
+	}
+	if got := params.Get("per-page"); got != "50" {
+		t.Errorf("per-page = %q, want 50", got)
+	}
+}
@@ -0,0 +1,146 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// ThreatEventIterator streams threat events page by page, fetching lazily
+// instead of buffering the full result set the way ListThreatEvents does.
+// It wraps a Paginator's channel-based worker, so both pull-style
+// consumption (Next/Event/Err) and the SDK's channel-based convention
+// (Events/Errors, usable with CollectAll) are available from the same
+// iterator. Close cancels the underlying fetch and must be called if the
+// caller stops iterating early.
+type ThreatEventIterator struct {
+	eventsCh <-chan ThreatEvent
+	errCh    <-chan error
+	cancel   context.CancelFunc
+
+	current ThreatEvent
+	err     error
+	closed  bool
+}
+
+// IterateThreatEvents returns a ThreatEventIterator over threat events
+// matching query. No request is made until the first call to Next,
+// Events, or Errors.
+func (c *Client) IterateThreatEvents(ctx context.Context, query *ThreatEventsQuery) *ThreatEventIterator {
+	if query == nil {
+		query = &ThreatEventsQuery{}
+	}
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.PerPage == 0 {
+		query.PerPage = c.config.PageSize
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	paginator := NewPaginator(func(ctx context.Context, pageToken string, header *http.Header) ([]ThreatEvent, string, error) {
+		return c.listThreatEventsPageToken(ctx, query, pageToken, header)
+	})
+	eventsCh, errCh := paginator.Stream(ctx)
+
+	return &ThreatEventIterator{
+		eventsCh: eventsCh,
+		errCh:    errCh,
+		cancel:   cancel,
+	}
+}
+
+// listThreatEventsPageToken adapts listThreatEventsPage to the
+// PageFetcher shape, encoding the next page number as an opaque token.
+func (c *Client) listThreatEventsPageToken(ctx context.Context, query *ThreatEventsQuery, pageToken string, header *http.Header) ([]ThreatEvent, string, error) {
+	page := query.Page
+	if pageToken != "" {
+		p, err := strconv.Atoi(pageToken)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid page token %q: %w", pageToken, err)
+		}
+		page = p
+	}
+
+	q := *query
+	q.Page = page
+
+	events, hasMore, err := c.listThreatEventsPage(ctx, &q, header)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if !hasMore {
+		return events, "", nil
+	}
+	return events, strconv.Itoa(page + 1), nil
+}
+
+// Next advances the iterator, blocking until the next event is
+// available, the underlying fetch fails, or ctx is canceled. It returns
+// false when iteration is complete; check Err to distinguish a clean
+// end from a failure.
+func (it *ThreatEventIterator) Next() bool {
+	if it.closed {
+		return false
+	}
+
+	select {
+	case event, ok := <-it.eventsCh:
+		if !ok {
+			select {
+			case err := <-it.errCh:
+				it.err = err
+			default:
+			}
+			return false
+		}
+		it.current = event
+		return true
+	case err := <-it.errCh:
+		if err != nil {
+			it.err = err
+		}
+		return false
+	}
+}
+
+// Event returns the event at the iterator's current position. It is
+// only valid after a call to Next that returned true.
+func (it *ThreatEventIterator) Event() ThreatEvent {
+	return it.current
+}
+
+// Err returns the first error encountered during iteration, if any.
+func (it *ThreatEventIterator) Err() error {
+	return it.err
+}
+
+// Events exposes the iterator's underlying item channel, for callers
+// that prefer the SDK's channel-based convention, e.g.
+// CollectAll(ctx, iter.Events(), iter.Errors()).
+func (it *ThreatEventIterator) Events() <-chan ThreatEvent {
+	return it.eventsCh
+}
+
+// Errors exposes the iterator's underlying error channel; see Events.
+func (it *ThreatEventIterator) Errors() <-chan error {
+	return it.errCh
+}
+
+// Close stops the iterator's background fetch. It is safe to call
+// multiple times and never returns an error; the method exists so
+// ThreatEventIterator satisfies the same shape as io.Closer for callers
+// that defer it unconditionally.
+func (it *ThreatEventIterator) Close() error {
+	if !it.closed {
+		it.closed = true
+		it.cancel()
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package sdk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/zalando/go-keyring"
+	"golang.org/x/oauth2"
+)
+
+// TokenCache persists an OAuth2 token between process invocations, so a
+// CLI or other short-lived process doesn't have to re-authenticate with
+// the token endpoint on every run. Load returns a nil token and a nil
+// error when no token is cached yet.
+type TokenCache interface {
+	Load() (*oauth2.Token, error)
+	Save(token *oauth2.Token) error
+}
+
+// FileTokenCache persists a token as JSON at Path. The file is written
+// with 0600 permissions since it contains a bearer credential.
+type FileTokenCache struct {
+	Path string
+}
+
+// NewFileTokenCache returns a FileTokenCache rooted at path.
+func NewFileTokenCache(path string) *FileTokenCache {
+	return &FileTokenCache{Path: path}
+}
+
+// Load implements TokenCache.
+func (f *FileTokenCache) Load() (*oauth2.Token, error) {
+	data, err := os.ReadFile(f.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading token cache: %w", err)
+	}
+
+	var token oauth2.Token
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, fmt.Errorf("parsing cached token: %w", err)
+	}
+	return &token, nil
+}
+
+// Save implements TokenCache.
+func (f *FileTokenCache) Save(token *oauth2.Token) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return fmt.Errorf("encoding token: %w", err)
+	}
+
+	if dir := filepath.Dir(f.Path); dir != "." {
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return fmt.Errorf("creating token cache directory: %w", err)
+		}
+	}
+
+	if err := os.WriteFile(f.Path, data, 0600); err != nil {
+		return fmt.Errorf("writing token cache: %w", err)
+	}
+	return nil
+}
+
+// KeyringTokenCache persists a token in the operating system's secret
+// store (macOS Keychain, Windows Credential Manager, or a
+// freedesktop.org Secret Service-compatible store on Linux) via
+// go-keyring, avoiding a plaintext file on disk.
+type KeyringTokenCache struct {
+	Service string
+	User    string
+}
+
+// NewKeyringTokenCache returns a KeyringTokenCache for the given service
+// and user/account name.
+func NewKeyringTokenCache(service, user string) *KeyringTokenCache {
+	return &KeyringTokenCache{Service: service, User: user}
+}
+
+// Load implements TokenCache.
+func (k *KeyringTokenCache) Load() (*oauth2.Token, error) {
+	data, err := keyring.Get(k.Service, k.User)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading token from keyring: %w", err)
+	}
+
+	var token oauth2.Token
+	if err := json.Unmarshal([]byte(data), &token); err != nil {
+		return nil, fmt.Errorf("parsing cached token: %w", err)
+	}
+	return &token, nil
+}
+
+// Save implements TokenCache.
+func (k *KeyringTokenCache) Save(token *oauth2.Token) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return fmt.Errorf("encoding token: %w", err)
+	}
+	if err := keyring.Set(k.Service, k.User, string(data)); err != nil {
+		return fmt.Errorf("writing token to keyring: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package sdk
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestFileTokenCacheLoadMissing(t *testing.T) {
+	cache := NewFileTokenCache(filepath.Join(t.TempDir(), "missing", "token.json"))
+
+	token, err := cache.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v, want nil for a missing cache file", err)
+	}
+	if token != nil {
+		t.Errorf("Load() = %+v, want nil", token)
+	}
+}
+
+func TestFileTokenCacheSaveAndLoad(t *testing.T) {
+	cache := NewFileTokenCache(filepath.Join(t.TempDir(), "nested", "token.json"))
+
+	want := &oauth2.Token{
+		AccessToken: "token-abc",
+		TokenType:   "Bearer",
+		Expiry:      time.Now().Add(time.Hour).UTC(),
+	}
+	if err := cache.Save(want); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	got, err := cache.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got == nil || got.AccessToken != want.AccessToken || !got.Expiry.Equal(want.Expiry) {
+		t.Errorf("Load() = %+v, want %+v", got, want)
+	}
+}
@@ -4,6 +4,8 @@
 
 package sdk
 
+import "time"
+
 // Common types
 
 // Severity levels for findings, detections, and events
@@ -120,6 +122,43 @@ type Vulnerability struct {
 	NVDCVSSV4Severity string         `json:"nvd_cvss_v4_severity,omitempty"`
 	NVDCVSSV4Score    string         `json:"nvd_cvss_v4_score,omitempty"`
 	ImpactMetrics     *ImpactMetrics `json:"impact_metrics,omitempty"`
+
+	// GHSAID is the GitHub Security Advisory identifier for this
+	// vulnerability (e.g. "GHSA-xxxx-yyyy-zzzz"), populated by
+	// EnrichVulnerability.
+	GHSAID string `json:"ghsa_id,omitempty"`
+	// Aliases lists every identifier this vulnerability is known by
+	// across advisory databases (CVE, GHSA, OSV, ...), populated by
+	// EnrichVulnerability.
+	Aliases []string `json:"aliases,omitempty"`
+	// EcosystemAdvisories holds the ecosystem-specific advisories (e.g.
+	// npm, PyPI) matched to this vulnerability, populated by
+	// EnrichVulnerability.
+	EcosystemAdvisories []EcosystemAdvisory `json:"ecosystem_advisories,omitempty"`
+	// VulnerableVersionRanges lists the affected and fixed version
+	// ranges per package ecosystem, populated by EnrichVulnerability.
+	VulnerableVersionRanges []VersionRange `json:"vulnerable_version_ranges,omitempty"`
+}
+
+// EcosystemAdvisory represents a GitHub Security Advisory as scoped to a
+// single package ecosystem (npm, PyPI, Go, ...).
+type EcosystemAdvisory struct {
+	GHSAID      string   `json:"ghsa_id"`
+	Summary     string   `json:"summary,omitempty"`
+	Severity    string   `json:"severity,omitempty"`
+	State       string   `json:"state,omitempty"`
+	CWEIDs      []string `json:"cwe_ids,omitempty"`
+	PublishedAt string   `json:"published_at,omitempty"`
+	UpdatedAt   string   `json:"updated_at,omitempty"`
+}
+
+// VersionRange describes the span of package versions affected by a
+// vulnerability within a single ecosystem.
+type VersionRange struct {
+	Ecosystem    string `json:"ecosystem"`
+	Introduced   string `json:"introduced,omitempty"`
+	Fixed        string `json:"fixed,omitempty"`
+	LastAffected string `json:"last_affected,omitempty"`
 }
 
 // ImpactMetrics represents the impact metrics for a vulnerability
@@ -437,6 +476,25 @@ type ThreatDetectionsQuery struct {
 	MaxFirstSeenTime string
 	MinLastSeenTime  string
 	MaxLastSeenTime  string
+	// MinFirstSeen, MaxFirstSeen, MinLastSeen, and MaxLastSeen are
+	// time.Time equivalents of the MinFirstSeenTime/MaxFirstSeenTime/
+	// MinLastSeenTime/MaxLastSeenTime fields above, formatted as RFC3339
+	// automatically. When both a string and time.Time field are set for
+	// the same bound, the time.Time field takes precedence.
+	MinFirstSeen *time.Time
+	MaxFirstSeen *time.Time
+	MinLastSeen  *time.Time
+	MaxLastSeen  *time.Time
+	// Filter is a composable predicate built with Eq, In, Between, Not,
+	// And, and Or, compiled into the API's query filter syntax. It is
+	// applied in addition to the fields above.
+	Filter *Filter
+	// SortBy orders results server-side by one or more fields, applied
+	// in the order given.
+	SortBy []SortField
+	// Fields restricts the response to a sparse fieldset. Empty returns
+	// every field.
+	Fields []string
 }
 
 // ThreatEventsQuery represents query parameters for threat events
@@ -448,8 +506,27 @@ type ThreatEventsQuery struct {
 	MaxFirstSeenTime string
 	MinLastSeenTime  string
 	MaxLastSeenTime  string
-	Page             int
-	PerPage          int
+	// MinFirstSeen, MaxFirstSeen, MinLastSeen, and MaxLastSeen are
+	// time.Time equivalents of the MinFirstSeenTime/MaxFirstSeenTime/
+	// MinLastSeenTime/MaxLastSeenTime fields above, formatted as RFC3339
+	// automatically. When both a string and time.Time field are set for
+	// the same bound, the time.Time field takes precedence.
+	MinFirstSeen *time.Time
+	MaxFirstSeen *time.Time
+	MinLastSeen  *time.Time
+	MaxLastSeen  *time.Time
+	// Filter is a composable predicate built with Eq, In, Between, Not,
+	// And, and Or, compiled into the API's query filter syntax. It is
+	// applied in addition to the fields above.
+	Filter *Filter
+	// SortBy orders results server-side by one or more fields, applied
+	// in the order given.
+	SortBy []SortField
+	// Fields restricts the response to a sparse fieldset. Empty returns
+	// every field.
+	Fields  []string
+	Page    int
+	PerPage int
 }
 
 // ApiEndpointsQuery represents query parameters for API endpoints
@@ -469,4 +546,26 @@ type ApiEndpointsQuery struct {
 	Domain                  string
 	ClusterID               string
 	Namespace               string
+
+	// Prefetch is how many pages ListApiEndpoints is allowed to fetch
+	// ahead of the slowest consumer, overlapping the network round-trip
+	// for page N+1 with the time the caller spends draining page N
+	// instead of blocking that fetch until the caller asks for more.
+	// Zero (the default) disables look-ahead and matches the original
+	// page-at-a-time behavior.
+	Prefetch int
+	// Concurrency bounds the number of goroutines used to drain
+	// prefetched pages into ListApiEndpoints' output channel, in case
+	// future per-item work makes that worth parallelizing; it does not
+	// increase the number of in-flight HTTP requests, since each page's
+	// token is only known once the previous page has been fetched.
+	// Zero means 1 (sequential draining).
+	Concurrency int
+	// PageTimeout bounds a single page fetch, independent of the
+	// context passed to the List call, so one slow page can't stall an
+	// otherwise-healthy stream forever; the overall context still
+	// bounds the whole operation. A page that times out is retried (see
+	// Client.SetPageDeadline for overriding it between pages). Zero
+	// disables the per-page bound.
+	PageTimeout time.Duration
 }
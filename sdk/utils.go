@@ -5,11 +5,33 @@
 package sdk
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"regexp"
 )
 
 var nextLinkRE = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
 
+// remarshal round-trips src through JSON into dst, so callers that
+// still hand in a map[string]interface{} can be served by a typed
+// method without duplicating its request-building logic. dst must be a
+// pointer. Unknown keys in src are rejected rather than silently
+// dropped, so a misspelled field still fails instead of being sent to
+// the API as an empty request.
+func remarshal(src interface{}, dst interface{}) error {
+	body, err := json.Marshal(src)
+	if err != nil {
+		return fmt.Errorf("marshaling request: %w", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(dst); err != nil {
+		return fmt.Errorf("decoding request: %w", err)
+	}
+	return nil
+}
+
 // extractNextLink parses the HTTP Link header and returns the "next" URL, if present.
 func extractNextLink(linkHeader string) (string, error) {
 	if linkHeader == "" {
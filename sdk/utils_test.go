@@ -8,6 +8,27 @@ import (
 	"testing"
 )
 
+func TestRemarshal(t *testing.T) {
+	t.Run("known fields decode", func(t *testing.T) {
+		var req UpdateThreatPolicyRequest
+		err := remarshal(map[string]interface{}{"enabled": true}, &req)
+		if err != nil {
+			t.Fatalf("remarshal() error = %v", err)
+		}
+		if req.Enabled == nil || !*req.Enabled {
+			t.Errorf("remarshal() Enabled = %v, want true", req.Enabled)
+		}
+	})
+
+	t.Run("unknown field rejected", func(t *testing.T) {
+		var req UpdateThreatPolicyRequest
+		err := remarshal(map[string]interface{}{"enbaled": true}, &req)
+		if err == nil {
+			t.Fatal("remarshal() error = nil, want error for unknown field")
+		}
+	})
+}
+
 func TestExtractNextLink(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -0,0 +1,142 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package webhook is the documented import path for verifying and
+// dispatching IntegrationWebhook deliveries. It re-exports
+// github.com/tektite-io/upwind-go-sdk/webhookserver's types under this
+// path rather than duplicating its HMAC-SHA256 signature verification
+// and replay-protection logic; see that package for the implementation.
+package webhook
+
+import (
+	"time"
+
+	"github.com/tektite-io/upwind-go-sdk/sdk"
+	"github.com/tektite-io/upwind-go-sdk/webhookserver"
+)
+
+// DefaultPath is the path Receiver mounts its handler on when no
+// WithPath option is given.
+const DefaultPath = webhookserver.DefaultPath
+
+// SignatureHeader is the header a delivery's HMAC-SHA256 signature is
+// sent in.
+const SignatureHeader = webhookserver.SignatureHeader
+
+// TimestampHeader is the header a delivery's send time is sent in, used
+// for replay protection.
+const TimestampHeader = webhookserver.TimestampHeader
+
+const (
+	// EventTypeThreatDetected is sent when a new threat detection is
+	// created or updated.
+	EventTypeThreatDetected = webhookserver.EventTypeThreatDetected
+	// EventTypeWorkflowTriggered is sent when a workflow's actions run.
+	EventTypeWorkflowTriggered = webhookserver.EventTypeWorkflowTriggered
+	// EventTypePolicyChanged is sent when a threat policy is enabled,
+	// disabled, or otherwise modified.
+	EventTypePolicyChanged = webhookserver.EventTypePolicyChanged
+	// EventTypeVulnerabilityFinding is sent when a new vulnerability
+	// finding is created or its severity changes.
+	EventTypeVulnerabilityFinding = webhookserver.EventTypeVulnerabilityFinding
+)
+
+type (
+	// EventType identifies the kind of payload carried by a delivery.
+	EventType = webhookserver.EventType
+	// Delivery is the envelope every webhook delivery is decoded into
+	// before its Payload is unmarshaled into a typed event.
+	Delivery = webhookserver.Delivery
+	// ThreatDetectedEvent is the payload of an EventTypeThreatDetected
+	// delivery.
+	ThreatDetectedEvent = webhookserver.ThreatDetectedEvent
+	// WorkflowTriggeredEvent is the payload of an
+	// EventTypeWorkflowTriggered delivery.
+	WorkflowTriggeredEvent = webhookserver.WorkflowTriggeredEvent
+	// PolicyChangedEvent is the payload of an EventTypePolicyChanged
+	// delivery.
+	PolicyChangedEvent = webhookserver.PolicyChangedEvent
+	// VulnerabilityFindingEvent is the payload of an
+	// EventTypeVulnerabilityFinding delivery.
+	VulnerabilityFindingEvent = webhookserver.VulnerabilityFindingEvent
+
+	// ThreatDetectionHandler handles a threat.detected delivery.
+	ThreatDetectionHandler = webhookserver.ThreatDetectionHandler
+	// WorkflowTriggeredHandler handles a workflow.triggered delivery.
+	WorkflowTriggeredHandler = webhookserver.WorkflowTriggeredHandler
+	// PolicyChangedHandler handles a policy.changed delivery.
+	PolicyChangedHandler = webhookserver.PolicyChangedHandler
+	// VulnerabilityFindingHandler handles a vulnerability.finding
+	// delivery.
+	VulnerabilityFindingHandler = webhookserver.VulnerabilityFindingHandler
+	// AnyHandler handles every delivery, regardless of Type.
+	AnyHandler = webhookserver.AnyHandler
+
+	// Mux decodes a Delivery's payload according to its Type and
+	// dispatches it to every handler registered for that type.
+	Mux = webhookserver.Mux
+
+	// SecretProvider resolves the shared secret to verify a delivery's
+	// signature against.
+	SecretProvider = webhookserver.SecretProvider
+
+	// Receiver verifies, decodes, and dispatches IntegrationWebhook
+	// deliveries during local development.
+	Receiver = webhookserver.Receiver
+	// Option customizes a Receiver constructed by NewReceiver.
+	Option = webhookserver.Option
+)
+
+// NewMux returns an empty Mux.
+func NewMux() *Mux {
+	return webhookserver.NewMux()
+}
+
+// StaticSecretProvider returns a SecretProvider that always resolves to
+// secret.
+func StaticSecretProvider(secret string) SecretProvider {
+	return webhookserver.StaticSecretProvider(secret)
+}
+
+// NewReceiver creates a Receiver that verifies deliveries against
+// secret (the shared secret configured on the IntegrationWebhook) and
+// uses client to satisfy Replay. client may be nil if Replay is never
+// called. Use WithSecretProvider instead of secret for dynamic secret
+// resolution.
+func NewReceiver(client *sdk.Client, secret string, opts ...Option) *Receiver {
+	return webhookserver.NewReceiver(client, secret, opts...)
+}
+
+// WithAddr sets the address Receiver.ListenAndServe binds to. Defaults
+// to ":8080".
+func WithAddr(addr string) Option {
+	return webhookserver.WithAddr(addr)
+}
+
+// WithPath sets the path Receiver's handler is mounted on, both by
+// ListenAndServe and by Mount. Defaults to DefaultPath.
+func WithPath(path string) Option {
+	return webhookserver.WithPath(path)
+}
+
+// WithLogger sets the Logger Receiver uses to report verification
+// failures and decode errors. Defaults to sdk.NoOpLogger.
+func WithLogger(logger sdk.Logger) Option {
+	return webhookserver.WithLogger(logger)
+}
+
+// WithSecretProvider overrides NewReceiver's static secret with a
+// dynamic SecretProvider, e.g. to look a secret up per delivery from a
+// secrets manager rather than fixing it at construction time.
+func WithSecretProvider(provider SecretProvider) Option {
+	return webhookserver.WithSecretProvider(provider)
+}
+
+// WithTimestampTolerance bounds how far a delivery's X-Upwind-Timestamp
+// header may drift from the receiver's clock before it's rejected as a
+// replay. Deliveries that omit the header are unaffected. Defaults to
+// 5 minutes.
+func WithTimestampTolerance(d time.Duration) Option {
+	return webhookserver.WithTimestampTolerance(d)
+}
@@ -15,6 +15,13 @@ import (
 
 // ListWorkflows retrieves all workflows
 func (c *Client) ListWorkflows(ctx context.Context) ([]Workflow, error) {
+	return c.listWorkflowsPage(ctx, nil)
+}
+
+// listWorkflowsPage retrieves all workflows. It writes the response
+// headers to header when non-nil, so IterateWorkflows can thread them
+// through Paginator.
+func (c *Client) listWorkflowsPage(ctx context.Context, header *http.Header) ([]Workflow, error) {
 	urlPath := fmt.Sprintf("%s/organizations/%s/workflows", c.config.GetBaseURL(), c.config.OrganizationID)
 
 	req, err := http.NewRequestWithContext(ctx, "GET", urlPath, nil)
@@ -28,9 +35,13 @@ func (c *Client) ListWorkflows(ctx context.Context) ([]Workflow, error) {
 	}
 	defer resp.Body.Close()
 
+	if header != nil {
+		*header = resp.Header
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+		return nil, decodeError(resp, body)
 	}
 
 	var workflows []Workflow
@@ -63,7 +74,7 @@ func (c *Client) GetWorkflow(ctx context.Context, workflowID string) (*Workflow,
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+		return nil, decodeError(resp, body)
 	}
 
 	var workflow Workflow
@@ -74,22 +85,52 @@ func (c *Client) GetWorkflow(ctx context.Context, workflowID string) (*Workflow,
 	return &workflow, nil
 }
 
-// CreateWorkflow creates a new workflow
+// CreateWorkflow creates a new workflow.
+//
+// Deprecated: use CreateWorkflowTyped with a CreateWorkflowRequest for
+// compile-time field checking. CreateWorkflow round-trips workflow
+// through JSON to build the typed request, so a misspelled key fails at
+// request time instead of at compile time.
 func (c *Client) CreateWorkflow(ctx context.Context, workflow map[string]interface{}) (*Workflow, error) {
+	var req CreateWorkflowRequest
+	if err := remarshal(workflow, &req); err != nil {
+		return nil, err
+	}
+	return c.CreateWorkflowTyped(ctx, &req)
+}
+
+// Validate checks that req has the fields required to create a
+// workflow.
+func (req *CreateWorkflowRequest) Validate() error {
+	if req.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if req.Type == "" {
+		return fmt.Errorf("type is required")
+	}
+	return nil
+}
+
+// CreateWorkflowTyped creates a new workflow from a typed request.
+func (c *Client) CreateWorkflowTyped(ctx context.Context, req *CreateWorkflowRequest) (*Workflow, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
 	urlPath := fmt.Sprintf("%s/organizations/%s/workflows", c.config.GetBaseURL(), c.config.OrganizationID)
 
-	body, err := json.Marshal(workflow)
+	body, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("marshaling request: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", urlPath, bytes.NewReader(body))
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", urlPath, bytes.NewReader(body))
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %w", err)
 	}
-	req.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.doRequest(ctx, req)
+	resp, err := c.doRequest(ctx, httpReq)
 	if err != nil {
 		return nil, fmt.Errorf("executing request: %w", err)
 	}
@@ -97,7 +138,7 @@ func (c *Client) CreateWorkflow(ctx context.Context, workflow map[string]interfa
 
 	if resp.StatusCode != http.StatusCreated {
 		respBody, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(respBody))
+		return nil, decodeError(resp, respBody)
 	}
 
 	var createdWorkflow Workflow
@@ -108,23 +149,54 @@ func (c *Client) CreateWorkflow(ctx context.Context, workflow map[string]interfa
 	return &createdWorkflow, nil
 }
 
-// UpdateWorkflow updates an existing workflow
+// UpdateWorkflow updates an existing workflow.
+//
+// Deprecated: use UpdateWorkflowTyped with an UpdateWorkflowRequest for
+// compile-time field checking. UpdateWorkflow round-trips update
+// through JSON to build the typed request, so a misspelled key fails at
+// request time instead of at compile time.
 func (c *Client) UpdateWorkflow(ctx context.Context, workflowID string, update map[string]interface{}) (*Workflow, error) {
+	var req UpdateWorkflowRequest
+	if err := remarshal(update, &req); err != nil {
+		return nil, err
+	}
+	return c.UpdateWorkflowTyped(ctx, workflowID, &req)
+}
+
+// Validate checks that req's Status, if set, is a recognized workflow
+// status.
+func (req *UpdateWorkflowRequest) Validate() error {
+	if req.Status != nil {
+		switch *req.Status {
+		case StatusEnabled, StatusDisabled, StatusArchived:
+		default:
+			return fmt.Errorf("invalid status: %s", *req.Status)
+		}
+	}
+	return nil
+}
+
+// UpdateWorkflowTyped updates an existing workflow from a typed request.
+func (c *Client) UpdateWorkflowTyped(ctx context.Context, workflowID string, req *UpdateWorkflowRequest) (*Workflow, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
 	urlPath := fmt.Sprintf("%s/organizations/%s/workflows/%s",
 		c.config.GetBaseURL(), c.config.OrganizationID, workflowID)
 
-	body, err := json.Marshal(update)
+	body, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("marshaling request: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "PATCH", urlPath, bytes.NewReader(body))
+	httpReq, err := http.NewRequestWithContext(ctx, "PATCH", urlPath, bytes.NewReader(body))
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %w", err)
 	}
-	req.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.doRequest(ctx, req)
+	resp, err := c.doRequest(ctx, httpReq)
 	if err != nil {
 		return nil, fmt.Errorf("executing request: %w", err)
 	}
@@ -136,7 +208,7 @@ func (c *Client) UpdateWorkflow(ctx context.Context, workflowID string, update m
 
 	if resp.StatusCode != http.StatusOK {
 		respBody, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(respBody))
+		return nil, decodeError(resp, respBody)
 	}
 
 	var workflow Workflow
@@ -169,7 +241,7 @@ func (c *Client) DeleteWorkflow(ctx context.Context, workflowID string) error {
 
 	if resp.StatusCode != http.StatusNoContent {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+		return decodeError(resp, body)
 	}
 
 	return nil
@@ -177,6 +249,13 @@ func (c *Client) DeleteWorkflow(ctx context.Context, workflowID string) error {
 
 // ListIntegrationWebhooks retrieves all integration webhooks
 func (c *Client) ListIntegrationWebhooks(ctx context.Context, vendor string) ([]IntegrationWebhook, error) {
+	return c.listIntegrationWebhooksPage(ctx, vendor, nil)
+}
+
+// listIntegrationWebhooksPage retrieves all integration webhooks. It
+// writes the response headers to header when non-nil, so
+// IterateIntegrationWebhooks can thread them through Paginator.
+func (c *Client) listIntegrationWebhooksPage(ctx context.Context, vendor string, header *http.Header) ([]IntegrationWebhook, error) {
 	urlPath := fmt.Sprintf("%s/organizations/%s/integration-webhooks", c.config.GetBaseURL(), c.config.OrganizationID)
 
 	if vendor != "" {
@@ -194,9 +273,13 @@ func (c *Client) ListIntegrationWebhooks(ctx context.Context, vendor string) ([]
 	}
 	defer resp.Body.Close()
 
+	if header != nil {
+		*header = resp.Header
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+		return nil, decodeError(resp, body)
 	}
 
 	var webhooks []IntegrationWebhook
@@ -207,22 +290,54 @@ func (c *Client) ListIntegrationWebhooks(ctx context.Context, vendor string) ([]
 	return webhooks, nil
 }
 
-// CreateIntegrationWebhook creates a new integration webhook
+// CreateIntegrationWebhook creates a new integration webhook.
+//
+// Deprecated: use CreateIntegrationWebhookTyped with a
+// CreateIntegrationWebhookRequest for compile-time field checking.
+// CreateIntegrationWebhook round-trips webhook through JSON to build
+// the typed request, so a misspelled key fails at request time instead
+// of at compile time.
 func (c *Client) CreateIntegrationWebhook(ctx context.Context, webhook map[string]interface{}) (*IntegrationWebhook, error) {
+	var req CreateIntegrationWebhookRequest
+	if err := remarshal(webhook, &req); err != nil {
+		return nil, err
+	}
+	return c.CreateIntegrationWebhookTyped(ctx, &req)
+}
+
+// Validate checks that req has the fields required to create an
+// integration webhook.
+func (req *CreateIntegrationWebhookRequest) Validate() error {
+	if req.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if req.Vendor == "" {
+		return fmt.Errorf("vendor is required")
+	}
+	return nil
+}
+
+// CreateIntegrationWebhookTyped creates a new integration webhook from
+// a typed request.
+func (c *Client) CreateIntegrationWebhookTyped(ctx context.Context, req *CreateIntegrationWebhookRequest) (*IntegrationWebhook, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
 	urlPath := fmt.Sprintf("%s/organizations/%s/integration-webhooks", c.config.GetBaseURL(), c.config.OrganizationID)
 
-	body, err := json.Marshal(webhook)
+	body, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("marshaling request: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", urlPath, bytes.NewReader(body))
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", urlPath, bytes.NewReader(body))
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %w", err)
 	}
-	req.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.doRequest(ctx, req)
+	resp, err := c.doRequest(ctx, httpReq)
 	if err != nil {
 		return nil, fmt.Errorf("executing request: %w", err)
 	}
@@ -230,7 +345,7 @@ func (c *Client) CreateIntegrationWebhook(ctx context.Context, webhook map[strin
 
 	if resp.StatusCode != http.StatusCreated {
 		respBody, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(respBody))
+		return nil, decodeError(resp, respBody)
 	}
 
 	var createdWebhook IntegrationWebhook
@@ -241,23 +356,56 @@ func (c *Client) CreateIntegrationWebhook(ctx context.Context, webhook map[strin
 	return &createdWebhook, nil
 }
 
-// UpdateIntegrationWebhook updates an existing integration webhook
+// UpdateIntegrationWebhook updates an existing integration webhook.
+//
+// Deprecated: use UpdateIntegrationWebhookTyped with an
+// UpdateIntegrationWebhookRequest for compile-time field checking.
+// UpdateIntegrationWebhook round-trips update through JSON to build the
+// typed request, so a misspelled key fails at request time instead of
+// at compile time.
 func (c *Client) UpdateIntegrationWebhook(ctx context.Context, webhookID string, update map[string]interface{}) (*IntegrationWebhook, error) {
+	var req UpdateIntegrationWebhookRequest
+	if err := remarshal(update, &req); err != nil {
+		return nil, err
+	}
+	return c.UpdateIntegrationWebhookTyped(ctx, webhookID, &req)
+}
+
+// Validate checks that req's Status, if set, is a recognized
+// integration webhook status.
+func (req *UpdateIntegrationWebhookRequest) Validate() error {
+	if req.Status != nil {
+		switch *req.Status {
+		case StatusEnabled, StatusDisabled:
+		default:
+			return fmt.Errorf("invalid status: %s", *req.Status)
+		}
+	}
+	return nil
+}
+
+// UpdateIntegrationWebhookTyped updates an existing integration webhook
+// from a typed request.
+func (c *Client) UpdateIntegrationWebhookTyped(ctx context.Context, webhookID string, req *UpdateIntegrationWebhookRequest) (*IntegrationWebhook, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
 	urlPath := fmt.Sprintf("%s/organizations/%s/integration-webhooks/%s",
 		c.config.GetBaseURL(), c.config.OrganizationID, webhookID)
 
-	body, err := json.Marshal(update)
+	body, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("marshaling request: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "PATCH", urlPath, bytes.NewReader(body))
+	httpReq, err := http.NewRequestWithContext(ctx, "PATCH", urlPath, bytes.NewReader(body))
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %w", err)
 	}
-	req.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.doRequest(ctx, req)
+	resp, err := c.doRequest(ctx, httpReq)
 	if err != nil {
 		return nil, fmt.Errorf("executing request: %w", err)
 	}
@@ -269,7 +417,7 @@ func (c *Client) UpdateIntegrationWebhook(ctx context.Context, webhookID string,
 
 	if resp.StatusCode != http.StatusOK {
 		respBody, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(respBody))
+		return nil, decodeError(resp, respBody)
 	}
 
 	var webhook IntegrationWebhook
@@ -302,7 +450,7 @@ func (c *Client) DeleteIntegrationWebhook(ctx context.Context, webhookID string)
 
 	if resp.StatusCode != http.StatusNoContent {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+		return decodeError(resp, body)
 	}
 
 	return nil
@@ -0,0 +1,109 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package sdk
+
+import (
+	"context"
+	"net/http"
+)
+
+// WorkflowIterator streams workflows, fetching lazily instead of
+// buffering the full result set the way ListWorkflows does. The
+// underlying endpoint has no server-side pagination, so iteration
+// fetches a single page on the first call to Next and then drains it;
+// see ThreatEventIterator for the shape this mirrors.
+type WorkflowIterator struct {
+	itemsCh <-chan Workflow
+	errCh   <-chan error
+	cancel  context.CancelFunc
+
+	current Workflow
+	err     error
+	closed  bool
+}
+
+// IterateWorkflows returns a WorkflowIterator over all workflows. No
+// request is made until the first call to Next, Workflows, or Errors.
+func (c *Client) IterateWorkflows(ctx context.Context) *WorkflowIterator {
+	ctx, cancel := context.WithCancel(ctx)
+	paginator := NewPaginator(func(ctx context.Context, pageToken string, header *http.Header) ([]Workflow, string, error) {
+		workflows, err := c.listWorkflowsPage(ctx, header)
+		if err != nil {
+			return nil, "", err
+		}
+		return workflows, "", nil
+	})
+	itemsCh, errCh := paginator.Stream(ctx)
+
+	return &WorkflowIterator{
+		itemsCh: itemsCh,
+		errCh:   errCh,
+		cancel:  cancel,
+	}
+}
+
+// Next advances the iterator, blocking until the next workflow is
+// available, the underlying fetch fails, or ctx is canceled. It returns
+// false when iteration is complete; check Err to distinguish a clean
+// end from a failure.
+func (it *WorkflowIterator) Next() bool {
+	if it.closed {
+		return false
+	}
+
+	select {
+	case workflow, ok := <-it.itemsCh:
+		if !ok {
+			select {
+			case err := <-it.errCh:
+				it.err = err
+			default:
+			}
+			return false
+		}
+		it.current = workflow
+		return true
+	case err := <-it.errCh:
+		if err != nil {
+			it.err = err
+		}
+		return false
+	}
+}
+
+// Workflow returns the workflow at the iterator's current position. It
+// is only valid after a call to Next that returned true.
+func (it *WorkflowIterator) Workflow() Workflow {
+	return it.current
+}
+
+// Err returns the first error encountered during iteration, if any.
+func (it *WorkflowIterator) Err() error {
+	return it.err
+}
+
+// Workflows exposes the iterator's underlying item channel, for callers
+// that prefer the SDK's channel-based convention, e.g.
+// CollectAll(ctx, iter.Workflows(), iter.Errors()).
+func (it *WorkflowIterator) Workflows() <-chan Workflow {
+	return it.itemsCh
+}
+
+// Errors exposes the iterator's underlying error channel; see Workflows.
+func (it *WorkflowIterator) Errors() <-chan error {
+	return it.errCh
+}
+
+// Close stops the iterator's background fetch. It is safe to call
+// multiple times and never returns an error; the method exists so
+// WorkflowIterator satisfies the same shape as io.Closer for callers
+// that defer it unconditionally.
+func (it *WorkflowIterator) Close() error {
+	if !it.closed {
+		it.closed = true
+		it.cancel()
+	}
+	return nil
+}
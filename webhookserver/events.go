@@ -0,0 +1,189 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package webhookserver receives and verifies IntegrationWebhook
+// deliveries from the Upwind API during local development, decoding
+// them into typed events and dispatching to user-registered handlers.
+package webhookserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/tektite-io/upwind-go-sdk/sdk"
+)
+
+// EventType identifies the kind of payload carried by a delivery.
+type EventType string
+
+const (
+	// EventTypeThreatDetected is sent when a new threat detection is
+	// created or updated.
+	EventTypeThreatDetected EventType = "threat.detected"
+	// EventTypeWorkflowTriggered is sent when a workflow's actions run.
+	EventTypeWorkflowTriggered EventType = "workflow.triggered"
+	// EventTypePolicyChanged is sent when a threat policy is enabled,
+	// disabled, or otherwise modified.
+	EventTypePolicyChanged EventType = "policy.changed"
+	// EventTypeVulnerabilityFinding is sent when a new vulnerability
+	// finding is created or its severity changes.
+	EventTypeVulnerabilityFinding EventType = "vulnerability.finding"
+)
+
+// Delivery is the envelope every webhook delivery is decoded into
+// before its Payload is unmarshaled into a typed event.
+type Delivery struct {
+	// ID uniquely identifies this delivery attempt. Receiver dedupes on
+	// this field, so retried deliveries only dispatch once.
+	ID string `json:"id"`
+	// Type selects which typed event Payload decodes into.
+	Type EventType `json:"type"`
+	// Time is when the event occurred, as reported by the sender.
+	Time string `json:"time"`
+	// Payload is the type-specific event body, decoded according to Type.
+	Payload json.RawMessage `json:"payload"`
+}
+
+// ThreatDetectedEvent is the payload of an EventTypeThreatDetected delivery.
+type ThreatDetectedEvent struct {
+	Detection sdk.ThreatDetection `json:"detection"`
+	// Replayed is true when this event was produced by Receiver.Replay
+	// rather than delivered over HTTP.
+	Replayed bool `json:"-"`
+}
+
+// WorkflowTriggeredEvent is the payload of an EventTypeWorkflowTriggered
+// delivery.
+type WorkflowTriggeredEvent struct {
+	Workflow  sdk.Workflow           `json:"workflow"`
+	Detection *sdk.ThreatDetection   `json:"detection,omitempty"`
+	Action    sdk.WorkflowAction     `json:"action,omitempty"`
+	Result    map[string]interface{} `json:"result,omitempty"`
+}
+
+// PolicyChangedEvent is the payload of an EventTypePolicyChanged delivery.
+type PolicyChangedEvent struct {
+	Policy sdk.ThreatPolicy `json:"policy"`
+}
+
+// VulnerabilityFindingEvent is the payload of an
+// EventTypeVulnerabilityFinding delivery.
+type VulnerabilityFindingEvent struct {
+	Finding sdk.VulnerabilityFinding `json:"finding"`
+}
+
+// ThreatDetectionHandler handles a threat.detected delivery.
+type ThreatDetectionHandler func(ctx context.Context, evt ThreatDetectedEvent)
+
+// WorkflowTriggeredHandler handles a workflow.triggered delivery.
+type WorkflowTriggeredHandler func(ctx context.Context, evt WorkflowTriggeredEvent)
+
+// PolicyChangedHandler handles a policy.changed delivery.
+type PolicyChangedHandler func(ctx context.Context, evt PolicyChangedEvent)
+
+// VulnerabilityFindingHandler handles a vulnerability.finding delivery.
+type VulnerabilityFindingHandler func(ctx context.Context, evt VulnerabilityFindingEvent)
+
+// AnyHandler handles every delivery, regardless of Type, in addition
+// to whichever typed handler also runs for it. Registered via
+// Mux.OnAny, it's useful for logging or dumping raw deliveries without
+// decoding each payload type individually.
+type AnyHandler func(ctx context.Context, delivery Delivery)
+
+// Mux decodes a Delivery's payload according to its Type and dispatches
+// it to every handler registered for that type, in registration order.
+// A Mux is safe for concurrent use by multiple goroutines as long as
+// handlers are all registered before Dispatch is first called.
+type Mux struct {
+	threatHandlers   []ThreatDetectionHandler
+	workflowHandlers []WorkflowTriggeredHandler
+	policyHandlers   []PolicyChangedHandler
+	vulnHandlers     []VulnerabilityFindingHandler
+	anyHandlers      []AnyHandler
+}
+
+// NewMux returns an empty Mux.
+func NewMux() *Mux {
+	return &Mux{}
+}
+
+// OnThreatDetection registers fn to run for every threat.detected
+// delivery, in addition to any handlers already registered.
+func (m *Mux) OnThreatDetection(fn ThreatDetectionHandler) {
+	m.threatHandlers = append(m.threatHandlers, fn)
+}
+
+// OnWorkflowTriggered registers fn to run for every workflow.triggered
+// delivery, in addition to any handlers already registered.
+func (m *Mux) OnWorkflowTriggered(fn WorkflowTriggeredHandler) {
+	m.workflowHandlers = append(m.workflowHandlers, fn)
+}
+
+// OnPolicyChanged registers fn to run for every policy.changed
+// delivery, in addition to any handlers already registered.
+func (m *Mux) OnPolicyChanged(fn PolicyChangedHandler) {
+	m.policyHandlers = append(m.policyHandlers, fn)
+}
+
+// OnVulnerabilityFinding registers fn to run for every
+// vulnerability.finding delivery, in addition to any handlers already
+// registered.
+func (m *Mux) OnVulnerabilityFinding(fn VulnerabilityFindingHandler) {
+	m.vulnHandlers = append(m.vulnHandlers, fn)
+}
+
+// OnAny registers fn to run for every delivery regardless of Type, in
+// addition to any handlers already registered.
+func (m *Mux) OnAny(fn AnyHandler) {
+	m.anyHandlers = append(m.anyHandlers, fn)
+}
+
+// Dispatch decodes delivery's Payload according to its Type and runs
+// every handler registered for that type, then every AnyHandler. It
+// returns an error if Type is unrecognized or Payload fails to decode;
+// registered handlers themselves don't return errors.
+func (m *Mux) Dispatch(ctx context.Context, delivery Delivery) error {
+	switch delivery.Type {
+	case EventTypeThreatDetected:
+		var evt ThreatDetectedEvent
+		if err := json.Unmarshal(delivery.Payload, &evt); err != nil {
+			return fmt.Errorf("decoding threat.detected payload: %w", err)
+		}
+		for _, fn := range m.threatHandlers {
+			fn(ctx, evt)
+		}
+	case EventTypeWorkflowTriggered:
+		var evt WorkflowTriggeredEvent
+		if err := json.Unmarshal(delivery.Payload, &evt); err != nil {
+			return fmt.Errorf("decoding workflow.triggered payload: %w", err)
+		}
+		for _, fn := range m.workflowHandlers {
+			fn(ctx, evt)
+		}
+	case EventTypePolicyChanged:
+		var evt PolicyChangedEvent
+		if err := json.Unmarshal(delivery.Payload, &evt); err != nil {
+			return fmt.Errorf("decoding policy.changed payload: %w", err)
+		}
+		for _, fn := range m.policyHandlers {
+			fn(ctx, evt)
+		}
+	case EventTypeVulnerabilityFinding:
+		var evt VulnerabilityFindingEvent
+		if err := json.Unmarshal(delivery.Payload, &evt); err != nil {
+			return fmt.Errorf("decoding vulnerability.finding payload: %w", err)
+		}
+		for _, fn := range m.vulnHandlers {
+			fn(ctx, evt)
+		}
+	default:
+		return fmt.Errorf("unrecognized event type: %q", delivery.Type)
+	}
+
+	for _, fn := range m.anyHandlers {
+		fn(ctx, delivery)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package webhookserver
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/tektite-io/upwind-go-sdk/sdk"
+)
+
+func TestMuxDispatchesVulnerabilityFinding(t *testing.T) {
+	mux := NewMux()
+
+	var got VulnerabilityFindingEvent
+	mux.OnVulnerabilityFinding(func(ctx context.Context, evt VulnerabilityFindingEvent) {
+		got = evt
+	})
+
+	payload, _ := json.Marshal(VulnerabilityFindingEvent{Finding: sdk.VulnerabilityFinding{ID: "vuln-1"}})
+	delivery := Delivery{ID: "delivery-1", Type: EventTypeVulnerabilityFinding, Payload: payload}
+
+	if err := mux.Dispatch(context.Background(), delivery); err != nil {
+		t.Fatalf("Dispatch() error = %v", err)
+	}
+	if got.Finding.ID != "vuln-1" {
+		t.Errorf("Finding.ID = %q, want vuln-1", got.Finding.ID)
+	}
+}
+
+func TestMuxOnAnyRunsForEveryType(t *testing.T) {
+	mux := NewMux()
+
+	var seenTypes []EventType
+	mux.OnAny(func(ctx context.Context, delivery Delivery) {
+		seenTypes = append(seenTypes, delivery.Type)
+	})
+
+	policyPayload, _ := json.Marshal(PolicyChangedEvent{Policy: sdk.ThreatPolicy{ID: "policy-1"}})
+	if err := mux.Dispatch(context.Background(), Delivery{Type: EventTypePolicyChanged, Payload: policyPayload}); err != nil {
+		t.Fatalf("Dispatch() error = %v", err)
+	}
+
+	vulnPayload, _ := json.Marshal(VulnerabilityFindingEvent{Finding: sdk.VulnerabilityFinding{ID: "vuln-1"}})
+	if err := mux.Dispatch(context.Background(), Delivery{Type: EventTypeVulnerabilityFinding, Payload: vulnPayload}); err != nil {
+		t.Fatalf("Dispatch() error = %v", err)
+	}
+
+	if len(seenTypes) != 2 || seenTypes[0] != EventTypePolicyChanged || seenTypes[1] != EventTypeVulnerabilityFinding {
+		t.Errorf("seenTypes = %v, want [policy.changed vulnerability.finding]", seenTypes)
+	}
+}
+
+func TestMuxDispatchUnrecognizedType(t *testing.T) {
+	mux := NewMux()
+	if err := mux.Dispatch(context.Background(), Delivery{Type: "unknown.type"}); err == nil {
+		t.Error("Dispatch() error = nil, want error for an unrecognized event type")
+	}
+}
@@ -0,0 +1,303 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package webhookserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/tektite-io/upwind-go-sdk/sdk"
+)
+
+// DefaultPath is the path Receiver mounts its handler on when no
+// WithPath option is given.
+const DefaultPath = "/webhooks/upwind"
+
+// maxBodyBytes bounds how much of a delivery's body Receiver will read,
+// to protect against a misbehaving or malicious sender.
+const maxBodyBytes = 1 << 20 // 1 MiB
+
+// defaultTimestampTolerance is how far a delivery's X-Upwind-Timestamp
+// header may drift from the receiver's clock before it's rejected as a
+// replay, when the sender includes that header.
+const defaultTimestampTolerance = 5 * time.Minute
+
+// SecretProvider resolves the shared secret to verify req's signature
+// against. It's called once per delivery, so a provider backed by a
+// secrets manager or a per-webhook-ID lookup can rotate secrets without
+// restarting the receiver.
+type SecretProvider interface {
+	Secret(ctx context.Context, req *http.Request) (string, error)
+}
+
+// staticSecretProvider always returns the same secret, for the common
+// case of a single webhook configured with one shared secret.
+type staticSecretProvider string
+
+func (s staticSecretProvider) Secret(ctx context.Context, req *http.Request) (string, error) {
+	return string(s), nil
+}
+
+// StaticSecretProvider returns a SecretProvider that always resolves to
+// secret.
+func StaticSecretProvider(secret string) SecretProvider {
+	return staticSecretProvider(secret)
+}
+
+// Receiver verifies, decodes, and dispatches IntegrationWebhook
+// deliveries during local development. A Receiver is safe for
+// concurrent use by multiple goroutines.
+type Receiver struct {
+	client             *sdk.Client
+	secretProvider     SecretProvider
+	timestampTolerance time.Duration
+	addr               string
+	path               string
+	logger             sdk.Logger
+	mux                *Mux
+
+	mu   sync.Mutex
+	seen map[string]struct{}
+}
+
+// Option customizes a Receiver constructed by NewReceiver.
+type Option func(*Receiver)
+
+// WithAddr sets the address Receiver.ListenAndServe binds to. Defaults
+// to ":8080".
+func WithAddr(addr string) Option {
+	return func(r *Receiver) {
+		r.addr = addr
+	}
+}
+
+// WithPath sets the path Receiver's handler is mounted on, both by
+// ListenAndServe and by Mount. Defaults to DefaultPath.
+func WithPath(path string) Option {
+	return func(r *Receiver) {
+		r.path = path
+	}
+}
+
+// WithLogger sets the Logger Receiver uses to report verification
+// failures and decode errors. Defaults to sdk.NoOpLogger.
+func WithLogger(logger sdk.Logger) Option {
+	return func(r *Receiver) {
+		r.logger = logger
+	}
+}
+
+// WithSecretProvider overrides NewReceiver's static secret with a
+// dynamic SecretProvider, e.g. to look a secret up per delivery from a
+// secrets manager rather than fixing it at construction time.
+func WithSecretProvider(provider SecretProvider) Option {
+	return func(r *Receiver) {
+		r.secretProvider = provider
+	}
+}
+
+// WithTimestampTolerance bounds how far a delivery's X-Upwind-Timestamp
+// header may drift from the receiver's clock before it's rejected as a
+// replay. Deliveries that omit the header are unaffected. Defaults to
+// 5 minutes.
+func WithTimestampTolerance(d time.Duration) Option {
+	return func(r *Receiver) {
+		r.timestampTolerance = d
+	}
+}
+
+// NewReceiver creates a Receiver that verifies deliveries against
+// secret (the shared secret configured on the IntegrationWebhook) and
+// uses client to satisfy Replay. client may be nil if Replay is never
+// called. Use WithSecretProvider instead of secret for dynamic secret
+// resolution.
+func NewReceiver(client *sdk.Client, secret string, opts ...Option) *Receiver {
+	r := &Receiver{
+		client:             client,
+		secretProvider:     StaticSecretProvider(secret),
+		timestampTolerance: defaultTimestampTolerance,
+		addr:               ":8080",
+		path:               DefaultPath,
+		logger:             sdk.NoOpLogger{},
+		mux:                NewMux(),
+		seen:               make(map[string]struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// OnThreatDetection registers fn to run for every threat.detected
+// delivery, in addition to any handlers already registered.
+func (r *Receiver) OnThreatDetection(fn ThreatDetectionHandler) {
+	r.mux.OnThreatDetection(fn)
+}
+
+// OnWorkflowTriggered registers fn to run for every workflow.triggered
+// delivery, in addition to any handlers already registered.
+func (r *Receiver) OnWorkflowTriggered(fn WorkflowTriggeredHandler) {
+	r.mux.OnWorkflowTriggered(fn)
+}
+
+// OnPolicyChanged registers fn to run for every policy.changed
+// delivery, in addition to any handlers already registered.
+func (r *Receiver) OnPolicyChanged(fn PolicyChangedHandler) {
+	r.mux.OnPolicyChanged(fn)
+}
+
+// OnVulnerabilityFinding registers fn to run for every
+// vulnerability.finding delivery, in addition to any handlers already
+// registered.
+func (r *Receiver) OnVulnerabilityFinding(fn VulnerabilityFindingHandler) {
+	r.mux.OnVulnerabilityFinding(fn)
+}
+
+// OnAny registers fn to run for every delivery regardless of Type, in
+// addition to any handlers already registered.
+func (r *Receiver) OnAny(fn AnyHandler) {
+	r.mux.OnAny(fn)
+}
+
+// Handler returns an http.Handler that verifies and dispatches
+// deliveries. Use this to mount the receiver on a path of your own
+// choosing, or call Mount to register it on path (see WithPath) of an
+// existing *http.ServeMux.
+func (r *Receiver) Handler() http.Handler {
+	return http.HandlerFunc(r.serveHTTP)
+}
+
+// Mount registers Receiver's handler on mux at path (see WithPath).
+func (r *Receiver) Mount(mux *http.ServeMux) {
+	mux.Handle(r.path, r.Handler())
+}
+
+// ListenAndServe starts an http.Server bound to addr (see WithAddr)
+// with Receiver's handler mounted at path (see WithPath). It blocks
+// until ctx is canceled, then gracefully shuts the server down and
+// returns nil, or returns the error that caused the server to exit.
+func (r *Receiver) ListenAndServe(ctx context.Context) error {
+	mux := http.NewServeMux()
+	r.Mount(mux)
+
+	server := &http.Server{
+		Addr:    r.addr,
+		Handler: mux,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		if err == http.ErrServerClosed {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+		return server.Shutdown(context.Background())
+	}
+}
+
+func (r *Receiver) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(req.Body, maxBodyBytes))
+	if err != nil {
+		http.Error(w, "reading body", http.StatusBadRequest)
+		return
+	}
+
+	secret, err := r.secretProvider.Secret(req.Context(), req)
+	if err != nil {
+		r.logger.Logf("webhookserver: resolving secret: %v", err)
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	timestamp := req.Header.Get(TimestampHeader)
+	if err := verifySignature(secret, body, timestamp, req.Header.Get(SignatureHeader)); err != nil {
+		r.logger.Logf("webhookserver: %v", err)
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+	if err := checkTimestampTolerance(timestamp, r.timestampTolerance); err != nil {
+		r.logger.Logf("webhookserver: %v", err)
+		http.Error(w, "stale delivery", http.StatusUnauthorized)
+		return
+	}
+
+	var delivery Delivery
+	if err := json.Unmarshal(body, &delivery); err != nil {
+		r.logger.Logf("webhookserver: decoding delivery: %v", err)
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	if r.alreadySeen(delivery.ID) {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if err := r.mux.Dispatch(req.Context(), delivery); err != nil {
+		r.logger.Logf("webhookserver: %v", err)
+		http.Error(w, "unprocessable delivery", http.StatusUnprocessableEntity)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// alreadySeen reports whether id has been dispatched before, recording
+// it as seen if not. A zero-value id (an empty delivery ID) is never
+// deduplicated.
+func (r *Receiver) alreadySeen(id string) bool {
+	if id == "" {
+		return false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.seen[id]; ok {
+		return true
+	}
+	r.seen[id] = struct{}{}
+	return false
+}
+
+// Replay fetches detectionID via GetThreatDetection and re-invokes the
+// registered ThreatDetectionHandlers as if it had just arrived as a
+// threat.detected delivery, so workflow logic can be exercised offline
+// against real historical detections. It requires a non-nil client to
+// have been passed to NewReceiver.
+func (r *Receiver) Replay(ctx context.Context, detectionID string) error {
+	if r.client == nil {
+		return fmt.Errorf("webhookserver: Replay requires a Client (pass one to NewReceiver)")
+	}
+
+	detection, err := r.client.GetThreatDetection(ctx, detectionID)
+	if err != nil {
+		return fmt.Errorf("webhookserver: fetching detection %s: %w", detectionID, err)
+	}
+
+	evt := ThreatDetectedEvent{Detection: *detection, Replayed: true}
+	for _, fn := range r.mux.threatHandlers {
+		fn(ctx, evt)
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package webhookserver
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/tektite-io/upwind-go-sdk/sdk"
+)
+
+const testSecret = "test-secret"
+
+func sign(body []byte) string {
+	mac := hmac.New(sha256.New, []byte(testSecret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func postDelivery(t *testing.T, handler http.Handler, delivery Delivery) *httptest.ResponseRecorder {
+	t.Helper()
+
+	body, err := json.Marshal(delivery)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, DefaultPath, bytes.NewReader(body))
+	req.Header.Set(SignatureHeader, sign(body))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestReceiverDispatchesThreatDetection(t *testing.T) {
+	r := NewReceiver(nil, testSecret)
+
+	var got ThreatDetectedEvent
+	r.OnThreatDetection(func(ctx context.Context, evt ThreatDetectedEvent) {
+		got = evt
+	})
+
+	payload, _ := json.Marshal(ThreatDetectedEvent{Detection: sdk.ThreatDetection{ID: "det-1", Severity: "HIGH"}})
+	delivery := Delivery{ID: "delivery-1", Type: EventTypeThreatDetected, Payload: payload}
+
+	rec := postDelivery(t, r.Handler(), delivery)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if got.Detection.ID != "det-1" {
+		t.Errorf("Detection.ID = %q, want %q", got.Detection.ID, "det-1")
+	}
+}
+
+func TestReceiverRejectsBadSignature(t *testing.T) {
+	r := NewReceiver(nil, testSecret)
+	r.OnThreatDetection(func(ctx context.Context, evt ThreatDetectedEvent) {
+		t.Error("handler should not run for an invalid signature")
+	})
+
+	delivery := Delivery{ID: "delivery-1", Type: EventTypeThreatDetected, Payload: json.RawMessage(`{}`)}
+	body, _ := json.Marshal(delivery)
+
+	req := httptest.NewRequest(http.MethodPost, DefaultPath, bytes.NewReader(body))
+	req.Header.Set(SignatureHeader, "sha256=deadbeef")
+
+	rec := httptest.NewRecorder()
+	r.Handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestReceiverDedupesByDeliveryID(t *testing.T) {
+	r := NewReceiver(nil, testSecret)
+
+	calls := 0
+	r.OnThreatDetection(func(ctx context.Context, evt ThreatDetectedEvent) {
+		calls++
+	})
+
+	payload, _ := json.Marshal(ThreatDetectedEvent{Detection: sdk.ThreatDetection{ID: "det-1"}})
+	delivery := Delivery{ID: "delivery-1", Type: EventTypeThreatDetected, Payload: payload}
+
+	postDelivery(t, r.Handler(), delivery)
+	postDelivery(t, r.Handler(), delivery)
+
+	if calls != 1 {
+		t.Errorf("handler called %d times, want 1", calls)
+	}
+}
+
+func TestReceiverWithSecretProvider(t *testing.T) {
+	r := NewReceiver(nil, "wrong-secret", WithSecretProvider(StaticSecretProvider(testSecret)))
+
+	var got ThreatDetectedEvent
+	r.OnThreatDetection(func(ctx context.Context, evt ThreatDetectedEvent) {
+		got = evt
+	})
+
+	payload, _ := json.Marshal(ThreatDetectedEvent{Detection: sdk.ThreatDetection{ID: "det-1"}})
+	delivery := Delivery{ID: "delivery-1", Type: EventTypeThreatDetected, Payload: payload}
+
+	rec := postDelivery(t, r.Handler(), delivery)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if got.Detection.ID != "det-1" {
+		t.Errorf("Detection.ID = %q, want det-1 (SecretProvider should override the constructor secret)", got.Detection.ID)
+	}
+}
+
+func TestReceiverRejectsStaleTimestamp(t *testing.T) {
+	r := NewReceiver(nil, testSecret, WithTimestampTolerance(time.Minute))
+	r.OnThreatDetection(func(ctx context.Context, evt ThreatDetectedEvent) {
+		t.Error("handler should not run for a stale timestamp")
+	})
+
+	delivery := Delivery{ID: "delivery-1", Type: EventTypeThreatDetected, Payload: json.RawMessage(`{}`)}
+	body, _ := json.Marshal(delivery)
+
+	timestamp := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(testSecret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	req := httptest.NewRequest(http.MethodPost, DefaultPath, bytes.NewReader(body))
+	req.Header.Set(SignatureHeader, signature)
+	req.Header.Set(TimestampHeader, timestamp)
+
+	rec := httptest.NewRecorder()
+	r.Handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte(`{"hello":"world"}`)
+
+	if err := verifySignature(testSecret, body, "", sign(body)); err != nil {
+		t.Errorf("verifySignature() error = %v, want nil", err)
+	}
+	if err := verifySignature(testSecret, body, "", "sha256=wrong"); err == nil {
+		t.Error("verifySignature() error = nil, want error for mismatched signature")
+	}
+	if err := verifySignature(testSecret, body, "", "wrong-prefix"); err == nil {
+		t.Error("verifySignature() error = nil, want error for missing prefix")
+	}
+}
+
+func TestVerifySignatureWithTimestamp(t *testing.T) {
+	body := []byte(`{"hello":"world"}`)
+	timestamp := "1700000000"
+
+	mac := hmac.New(sha256.New, []byte(testSecret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	if err := verifySignature(testSecret, body, timestamp, signature); err != nil {
+		t.Errorf("verifySignature() error = %v, want nil", err)
+	}
+	if err := verifySignature(testSecret, body, "", signature); err == nil {
+		t.Error("verifySignature() error = nil, want error when timestamp is omitted from the signed message")
+	}
+}
+
+func TestCheckTimestampTolerance(t *testing.T) {
+	if err := checkTimestampTolerance("", time.Minute); err != nil {
+		t.Errorf("checkTimestampTolerance() error = %v, want nil for an empty timestamp", err)
+	}
+
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	if err := checkTimestampTolerance(now, time.Minute); err != nil {
+		t.Errorf("checkTimestampTolerance() error = %v, want nil for a fresh timestamp", err)
+	}
+
+	old := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+	if err := checkTimestampTolerance(old, time.Minute); err == nil {
+		t.Error("checkTimestampTolerance() error = nil, want error for a stale timestamp")
+	}
+}
@@ -0,0 +1,275 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package workflows
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/tektite-io/upwind-go-sdk/sdk"
+)
+
+// ApplyOptions configures Apply.
+type ApplyOptions struct {
+	// Concurrency bounds how many actions Apply executes at once.
+	// Defaults to 1 (sequential) when <= 0.
+	Concurrency int
+	// RollbackOnError, when true, attempts to undo every action already
+	// applied in this call as soon as one action fails, before Apply
+	// returns. Rollback is best-effort: a rollback failure is recorded
+	// in the returned Result but does not replace the original error.
+	RollbackOnError bool
+}
+
+// AppliedAction records the outcome of executing, or rolling back, one
+// planned action.
+type AppliedAction struct {
+	// Kind is "workflow", "policy", or "webhook".
+	Kind string
+	Type ActionType
+	Key  string
+	// Err is nil on success.
+	Err error
+}
+
+// Result is the outcome of an Apply call.
+type Result struct {
+	Applied    []AppliedAction
+	RolledBack []AppliedAction
+}
+
+// Apply executes plan's actions through the Workflow, ThreatPolicy, and
+// IntegrationWebhook CRUD methods, running up to opts.Concurrency at
+// once. If any action fails and opts.RollbackOnError is set, Apply
+// attempts to undo every action it already applied in this call before
+// returning the original error.
+func Apply(ctx context.Context, client *sdk.Client, plan *Plan, opts ApplyOptions) (*Result, error) {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	result := &Result{}
+	var mu sync.Mutex
+	var appliedWorkflows []*WorkflowAction
+	var appliedPolicies []*PolicyAction
+	var appliedWebhooks []*WebhookAction
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(concurrency)
+
+	for i := range plan.Workflows {
+		action := &plan.Workflows[i]
+		if action.Type == ActionNoop {
+			continue
+		}
+		g.Go(func() error {
+			err := applyWorkflowAction(gctx, client, action)
+			mu.Lock()
+			result.Applied = append(result.Applied, AppliedAction{Kind: "workflow", Type: action.Type, Key: action.Key, Err: err})
+			if err == nil {
+				appliedWorkflows = append(appliedWorkflows, action)
+			}
+			mu.Unlock()
+			return err
+		})
+	}
+
+	for i := range plan.Policies {
+		action := &plan.Policies[i]
+		if action.Type == ActionNoop {
+			continue
+		}
+		g.Go(func() error {
+			err := applyPolicyAction(gctx, client, action)
+			mu.Lock()
+			result.Applied = append(result.Applied, AppliedAction{Kind: "policy", Type: action.Type, Key: action.Key, Err: err})
+			if err == nil {
+				appliedPolicies = append(appliedPolicies, action)
+			}
+			mu.Unlock()
+			return err
+		})
+	}
+
+	for i := range plan.Webhooks {
+		action := &plan.Webhooks[i]
+		if action.Type == ActionNoop {
+			continue
+		}
+		g.Go(func() error {
+			err := applyWebhookAction(gctx, client, action)
+			mu.Lock()
+			result.Applied = append(result.Applied, AppliedAction{Kind: "webhook", Type: action.Type, Key: action.Key, Err: err})
+			if err == nil {
+				appliedWebhooks = append(appliedWebhooks, action)
+			}
+			mu.Unlock()
+			return err
+		})
+	}
+
+	applyErr := g.Wait()
+	if applyErr != nil && opts.RollbackOnError {
+		rollback(client, appliedWorkflows, appliedPolicies, appliedWebhooks, result)
+	}
+
+	return result, applyErr
+}
+
+// rollback attempts to undo every already-applied action, in reverse
+// order, using a fresh background context so an expired or canceled
+// ctx doesn't also abort cleanup.
+func rollback(client *sdk.Client, workflows []*WorkflowAction, policies []*PolicyAction, webhooks []*WebhookAction, result *Result) {
+	ctx := context.Background()
+
+	for i := len(workflows) - 1; i >= 0; i-- {
+		action := workflows[i]
+		err := rollbackWorkflowAction(ctx, client, action)
+		result.RolledBack = append(result.RolledBack, AppliedAction{Kind: "workflow", Type: action.Type, Key: action.Key, Err: err})
+	}
+	for i := len(policies) - 1; i >= 0; i-- {
+		action := policies[i]
+		err := rollbackPolicyAction(ctx, client, action)
+		result.RolledBack = append(result.RolledBack, AppliedAction{Kind: "policy", Type: action.Type, Key: action.Key, Err: err})
+	}
+	for i := len(webhooks) - 1; i >= 0; i-- {
+		action := webhooks[i]
+		err := rollbackWebhookAction(ctx, client, action)
+		result.RolledBack = append(result.RolledBack, AppliedAction{Kind: "webhook", Type: action.Type, Key: action.Key, Err: err})
+	}
+}
+
+func applyWorkflowAction(ctx context.Context, client *sdk.Client, action *WorkflowAction) error {
+	switch action.Type {
+	case ActionCreate:
+		created, err := client.CreateWorkflowTyped(ctx, &sdk.CreateWorkflowRequest{
+			Name:   action.Definition.Name,
+			Type:   action.Definition.Type,
+			Config: action.Definition.Config,
+		})
+		if err != nil {
+			return fmt.Errorf("creating workflow %q: %w", action.Key, err)
+		}
+		action.ID = created.ID
+		return nil
+	case ActionUpdate:
+		req := &sdk.UpdateWorkflowRequest{Config: action.Definition.Config}
+		if action.Definition.Status != "" {
+			req.Status = &action.Definition.Status
+		}
+		if _, err := client.UpdateWorkflowTyped(ctx, action.ID, req); err != nil {
+			return fmt.Errorf("updating workflow %q: %w", action.Key, err)
+		}
+		return nil
+	case ActionDelete:
+		if err := client.DeleteWorkflow(ctx, action.ID); err != nil {
+			return fmt.Errorf("deleting workflow %q: %w", action.Key, err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported action type: %s", action.Type)
+	}
+}
+
+// rollbackWorkflowAction undoes a successfully applied action: a create
+// is undone by deleting the resource it produced, an update by
+// restoring the previous values, and a delete by recreating the
+// resource from its previous state (with a new ID).
+func rollbackWorkflowAction(ctx context.Context, client *sdk.Client, action *WorkflowAction) error {
+	switch action.Type {
+	case ActionCreate:
+		return client.DeleteWorkflow(ctx, action.ID)
+	case ActionUpdate:
+		if action.Previous == nil {
+			return fmt.Errorf("no previous state recorded for workflow %q", action.Key)
+		}
+		status := action.Previous.Status
+		_, err := client.UpdateWorkflowTyped(ctx, action.ID, &sdk.UpdateWorkflowRequest{Status: &status, Config: action.Previous.Config})
+		return err
+	case ActionDelete:
+		if action.Previous == nil {
+			return fmt.Errorf("no previous state recorded for workflow %q", action.Key)
+		}
+		_, err := client.CreateWorkflowTyped(ctx, &sdk.CreateWorkflowRequest{Name: action.Previous.Name, Type: action.Previous.Type, Config: action.Previous.Config})
+		return err
+	default:
+		return nil
+	}
+}
+
+func applyPolicyAction(ctx context.Context, client *sdk.Client, action *PolicyAction) error {
+	enabled := action.Definition.Enabled
+	if _, err := client.UpdateThreatPolicyTyped(ctx, action.ID, &sdk.UpdateThreatPolicyRequest{Enabled: &enabled}); err != nil {
+		return fmt.Errorf("updating policy %q: %w", action.Key, err)
+	}
+	return nil
+}
+
+func rollbackPolicyAction(ctx context.Context, client *sdk.Client, action *PolicyAction) error {
+	if action.Previous == nil {
+		return fmt.Errorf("no previous state recorded for policy %q", action.Key)
+	}
+	enabled := action.Previous.Enabled
+	_, err := client.UpdateThreatPolicyTyped(ctx, action.ID, &sdk.UpdateThreatPolicyRequest{Enabled: &enabled})
+	return err
+}
+
+func applyWebhookAction(ctx context.Context, client *sdk.Client, action *WebhookAction) error {
+	switch action.Type {
+	case ActionCreate:
+		created, err := client.CreateIntegrationWebhookTyped(ctx, &sdk.CreateIntegrationWebhookRequest{
+			Name:   action.Definition.Name,
+			Vendor: action.Definition.Vendor,
+			Config: mergedWebhookConfig(action.Definition),
+		})
+		if err != nil {
+			return fmt.Errorf("creating webhook %q: %w", action.Key, err)
+		}
+		action.ID = created.ID
+		return nil
+	case ActionUpdate:
+		req := &sdk.UpdateIntegrationWebhookRequest{Config: mergedWebhookConfig(action.Definition)}
+		if action.Definition.Status != "" {
+			req.Status = &action.Definition.Status
+		}
+		if _, err := client.UpdateIntegrationWebhookTyped(ctx, action.ID, req); err != nil {
+			return fmt.Errorf("updating webhook %q: %w", action.Key, err)
+		}
+		return nil
+	case ActionDelete:
+		if err := client.DeleteIntegrationWebhook(ctx, action.ID); err != nil {
+			return fmt.Errorf("deleting webhook %q: %w", action.Key, err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported action type: %s", action.Type)
+	}
+}
+
+func rollbackWebhookAction(ctx context.Context, client *sdk.Client, action *WebhookAction) error {
+	switch action.Type {
+	case ActionCreate:
+		return client.DeleteIntegrationWebhook(ctx, action.ID)
+	case ActionUpdate:
+		if action.Previous == nil {
+			return fmt.Errorf("no previous state recorded for webhook %q", action.Key)
+		}
+		status := action.Previous.Status
+		_, err := client.UpdateIntegrationWebhookTyped(ctx, action.ID, &sdk.UpdateIntegrationWebhookRequest{Status: &status, Config: action.Previous.Config})
+		return err
+	case ActionDelete:
+		if action.Previous == nil {
+			return fmt.Errorf("no previous state recorded for webhook %q", action.Key)
+		}
+		_, err := client.CreateIntegrationWebhookTyped(ctx, &sdk.CreateIntegrationWebhookRequest{Name: action.Previous.Name, Vendor: action.Previous.Vendor, Config: action.Previous.Config})
+		return err
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,127 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package workflows
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/hashicorp/hcl/v2/hclsimple"
+	"gopkg.in/yaml.v3"
+
+	"github.com/tektite-io/upwind-go-sdk/sdk"
+)
+
+// ParseYAML decodes a Manifest from r's upwind.yaml contents.
+func ParseYAML(r io.Reader) (*Manifest, error) {
+	var m Manifest
+	if err := yaml.NewDecoder(r).Decode(&m); err != nil {
+		return nil, fmt.Errorf("decoding yaml manifest: %w", err)
+	}
+	return &m, nil
+}
+
+// WriteYAML encodes m to w in upwind.yaml form.
+func (m *Manifest) WriteYAML(w io.Writer) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	defer enc.Close()
+
+	if err := enc.Encode(m); err != nil {
+		return fmt.Errorf("encoding yaml manifest: %w", err)
+	}
+	return nil
+}
+
+// hclManifest mirrors Manifest with HCL block syntax:
+//
+//	workflow "nightly-scan-alert" {
+//	  type   = "notification"
+//	  status = "ENABLED"
+//	}
+//
+//	policy "policy-123" {
+//	  enabled = true
+//	}
+//
+//	webhook "slack-security" {
+//	  vendor      = "slack"
+//	  config_json = "{\"channel\":\"#security\"}"
+//	}
+//
+// hclsimple can only decode block fields into types that carry their
+// own hcl tags, so WorkflowConfig and the webhook's free-form Config
+// (generated and untagged, respectively) are authored as a config_json
+// attribute and unmarshaled separately.
+type hclManifest struct {
+	Workflows []hclWorkflow `hcl:"workflow,block"`
+	Policies  []hclPolicy   `hcl:"policy,block"`
+	Webhooks  []hclWebhook  `hcl:"webhook,block"`
+}
+
+type hclWorkflow struct {
+	Name       string `hcl:"name,label"`
+	Type       string `hcl:"type"`
+	Status     string `hcl:"status,optional"`
+	ConfigJSON string `hcl:"config_json,optional"`
+}
+
+type hclPolicy struct {
+	ID      string `hcl:"id,label"`
+	Enabled bool   `hcl:"enabled"`
+}
+
+type hclWebhook struct {
+	Name       string `hcl:"name,label"`
+	ExternalID string `hcl:"external_id,optional"`
+	Vendor     string `hcl:"vendor"`
+	Status     string `hcl:"status,optional"`
+	ConfigJSON string `hcl:"config_json,optional"`
+}
+
+// ParseHCL decodes a Manifest from an HCL document. filename is used
+// only to attribute parse diagnostics.
+func ParseHCL(filename string, src []byte) (*Manifest, error) {
+	var parsed hclManifest
+	if err := hclsimple.Decode(filename, src, nil, &parsed); err != nil {
+		return nil, fmt.Errorf("decoding hcl manifest: %w", err)
+	}
+	return parsed.toManifest()
+}
+
+func (h hclManifest) toManifest() (*Manifest, error) {
+	m := &Manifest{
+		Policies: make([]PolicyDefinition, len(h.Policies)),
+	}
+
+	for _, wf := range h.Workflows {
+		def := Definition{Name: wf.Name, Type: wf.Type, Status: wf.Status}
+		if wf.ConfigJSON != "" {
+			var cfg sdk.WorkflowConfig
+			if err := json.Unmarshal([]byte(wf.ConfigJSON), &cfg); err != nil {
+				return nil, fmt.Errorf("workflow %q: decoding config_json: %w", wf.Name, err)
+			}
+			def.Config = &cfg
+		}
+		m.Workflows = append(m.Workflows, def)
+	}
+
+	for i, p := range h.Policies {
+		m.Policies[i] = PolicyDefinition{ID: p.ID, Enabled: p.Enabled}
+	}
+
+	for _, wh := range h.Webhooks {
+		def := WebhookDefinition{ExternalID: wh.ExternalID, Name: wh.Name, Vendor: wh.Vendor, Status: wh.Status}
+		if wh.ConfigJSON != "" {
+			if err := json.Unmarshal([]byte(wh.ConfigJSON), &def.Config); err != nil {
+				return nil, fmt.Errorf("webhook %q: decoding config_json: %w", wh.Name, err)
+			}
+		}
+		m.Webhooks = append(m.Webhooks, def)
+	}
+
+	return m, nil
+}
@@ -0,0 +1,95 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package workflows
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestParseYAMLAndWriteYAMLRoundTrip(t *testing.T) {
+	src := `
+workflows:
+  - name: nightly-scan-alert
+    type: notification
+    status: ENABLED
+policies:
+  - id: policy-123
+    enabled: true
+webhooks:
+  - name: slack-security
+    vendor: slack
+    external_id: ext-1
+`
+	m, err := ParseYAML(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ParseYAML() error = %v", err)
+	}
+
+	if len(m.Workflows) != 1 || m.Workflows[0].Name != "nightly-scan-alert" {
+		t.Fatalf("Workflows = %+v", m.Workflows)
+	}
+	if len(m.Policies) != 1 || !m.Policies[0].Enabled {
+		t.Fatalf("Policies = %+v", m.Policies)
+	}
+	if len(m.Webhooks) != 1 || m.Webhooks[0].ExternalID != "ext-1" {
+		t.Fatalf("Webhooks = %+v", m.Webhooks)
+	}
+
+	var buf bytes.Buffer
+	if err := m.WriteYAML(&buf); err != nil {
+		t.Fatalf("WriteYAML() error = %v", err)
+	}
+
+	roundTripped, err := ParseYAML(&buf)
+	if err != nil {
+		t.Fatalf("ParseYAML(WriteYAML()) error = %v", err)
+	}
+	if roundTripped.Workflows[0].Name != m.Workflows[0].Name {
+		t.Errorf("round trip lost Workflows[0].Name: got %+v", roundTripped.Workflows)
+	}
+}
+
+func TestHCLManifestToManifest(t *testing.T) {
+	parsed := hclManifest{
+		Workflows: []hclWorkflow{
+			{Name: "nightly-scan-alert", Type: "notification", Status: "ENABLED", ConfigJSON: `{"trigger":{}}`},
+		},
+		Policies: []hclPolicy{
+			{ID: "policy-123", Enabled: true},
+		},
+		Webhooks: []hclWebhook{
+			{Name: "slack-security", Vendor: "slack", ExternalID: "ext-1", ConfigJSON: `{"channel":"#security"}`},
+		},
+	}
+
+	m, err := parsed.toManifest()
+	if err != nil {
+		t.Fatalf("toManifest() error = %v", err)
+	}
+
+	if len(m.Workflows) != 1 || m.Workflows[0].Config == nil {
+		t.Fatalf("Workflows = %+v", m.Workflows)
+	}
+	if len(m.Policies) != 1 || m.Policies[0].ID != "policy-123" {
+		t.Fatalf("Policies = %+v", m.Policies)
+	}
+	if len(m.Webhooks) != 1 || m.Webhooks[0].Config["channel"] != "#security" {
+		t.Fatalf("Webhooks = %+v", m.Webhooks)
+	}
+}
+
+func TestHCLManifestToManifestInvalidConfigJSON(t *testing.T) {
+	parsed := hclManifest{
+		Webhooks: []hclWebhook{
+			{Name: "slack-security", Vendor: "slack", ConfigJSON: `not json`},
+		},
+	}
+
+	if _, err := parsed.toManifest(); err == nil {
+		t.Error("toManifest() error = nil, want error for invalid config_json")
+	}
+}
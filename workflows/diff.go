@@ -0,0 +1,51 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package workflows
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FieldDiff describes one field that differs between a live resource's
+// current value and a Definition's desired value.
+type FieldDiff struct {
+	Field string
+	From  string
+	To    string
+}
+
+// String renders d as a single human-readable line, e.g.
+// `status: "ENABLED" -> "DISABLED"`.
+func (d FieldDiff) String() string {
+	return fmt.Sprintf("%s: %q -> %q", d.Field, d.From, d.To)
+}
+
+// formatDiffs joins diffs into a multi-line human-readable diff, one
+// field change per line, or "" if diffs is empty.
+func formatDiffs(diffs []FieldDiff) string {
+	lines := make([]string, len(diffs))
+	for i, d := range diffs {
+		lines[i] = d.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// diffString compares a live field's current value against a
+// definition's desired value, returning nil if they match.
+func diffString(field, from, to string) *FieldDiff {
+	if from == to {
+		return nil
+	}
+	return &FieldDiff{Field: field, From: from, To: to}
+}
+
+// appendDiff appends d to diffs if d is non-nil.
+func appendDiff(diffs []FieldDiff, d *FieldDiff) []FieldDiff {
+	if d == nil {
+		return diffs
+	}
+	return append(diffs, *d)
+}
@@ -0,0 +1,54 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package workflows
+
+import "testing"
+
+func TestFieldDiffString(t *testing.T) {
+	d := FieldDiff{Field: "status", From: "ENABLED", To: "DISABLED"}
+	want := `status: "ENABLED" -> "DISABLED"`
+	if got := d.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestFormatDiffs(t *testing.T) {
+	diffs := []FieldDiff{
+		{Field: "type", From: "notification", To: "quarantine"},
+		{Field: "status", From: "ENABLED", To: "DISABLED"},
+	}
+	want := "type: \"notification\" -> \"quarantine\"\nstatus: \"ENABLED\" -> \"DISABLED\""
+	if got := formatDiffs(diffs); got != want {
+		t.Errorf("formatDiffs() = %q, want %q", got, want)
+	}
+
+	if got := formatDiffs(nil); got != "" {
+		t.Errorf("formatDiffs(nil) = %q, want empty", got)
+	}
+}
+
+func TestDiffString(t *testing.T) {
+	if d := diffString("name", "a", "a"); d != nil {
+		t.Errorf("diffString() = %v, want nil for equal values", d)
+	}
+
+	d := diffString("name", "a", "b")
+	if d == nil || d.From != "a" || d.To != "b" {
+		t.Errorf("diffString() = %v, want {From: a, To: b}", d)
+	}
+}
+
+func TestAppendDiff(t *testing.T) {
+	var diffs []FieldDiff
+	diffs = appendDiff(diffs, nil)
+	if len(diffs) != 0 {
+		t.Fatalf("appendDiff(nil) added an entry: %v", diffs)
+	}
+
+	diffs = appendDiff(diffs, diffString("name", "a", "b"))
+	if len(diffs) != 1 {
+		t.Fatalf("appendDiff() len = %d, want 1", len(diffs))
+	}
+}
@@ -0,0 +1,310 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package workflows
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/tektite-io/upwind-go-sdk/sdk"
+)
+
+// ActionType is the kind of change Sync decided a resource needs.
+type ActionType string
+
+const (
+	// ActionNoop means the live resource already matches its Definition.
+	ActionNoop ActionType = "noop"
+	// ActionCreate means no live resource matches the Definition's key.
+	ActionCreate ActionType = "create"
+	// ActionUpdate means a live resource exists but differs from its
+	// Definition; Diffs describes the changed fields.
+	ActionUpdate ActionType = "update"
+	// ActionDelete means a live resource exists with no matching
+	// Definition in the manifest.
+	ActionDelete ActionType = "delete"
+)
+
+// WorkflowAction is one planned change to a Workflow.
+type WorkflowAction struct {
+	Type ActionType
+	Key  string
+	// ID is the live workflow's ID. Empty until ActionCreate is applied.
+	ID         string
+	Definition Definition
+	// Previous is the live state before the change, or nil for
+	// ActionCreate.
+	Previous *sdk.Workflow
+	Diffs    []FieldDiff
+}
+
+// String renders a's human-readable diff, one changed field per line.
+func (a WorkflowAction) String() string {
+	return formatDiffs(a.Diffs)
+}
+
+// PolicyAction is one planned change to a ThreatPolicy. Policies can't
+// be created or deleted through the API, so Type is always
+// ActionNoop or ActionUpdate.
+type PolicyAction struct {
+	Type       ActionType
+	Key        string
+	ID         string
+	Definition PolicyDefinition
+	Previous   *sdk.ThreatPolicy
+	Diffs      []FieldDiff
+}
+
+// String renders a's human-readable diff, one changed field per line.
+func (a PolicyAction) String() string {
+	return formatDiffs(a.Diffs)
+}
+
+// WebhookAction is one planned change to an IntegrationWebhook.
+type WebhookAction struct {
+	Type       ActionType
+	Key        string
+	ID         string
+	Definition WebhookDefinition
+	Previous   *sdk.IntegrationWebhook
+	Diffs      []FieldDiff
+}
+
+// String renders a's human-readable diff, one changed field per line.
+func (a WebhookAction) String() string {
+	return formatDiffs(a.Diffs)
+}
+
+// Plan is the set of create/update/delete actions Sync computed by
+// diffing a Manifest against live API state. Sync never mutates
+// anything; only Apply does, mirroring Terraform's plan/apply split.
+type Plan struct {
+	Workflows []WorkflowAction
+	Policies  []PolicyAction
+	Webhooks  []WebhookAction
+}
+
+// IsEmpty reports whether every action in p is a no-op.
+func (p *Plan) IsEmpty() bool {
+	for _, a := range p.Workflows {
+		if a.Type != ActionNoop {
+			return false
+		}
+	}
+	for _, a := range p.Policies {
+		if a.Type != ActionNoop {
+			return false
+		}
+	}
+	for _, a := range p.Webhooks {
+		if a.Type != ActionNoop {
+			return false
+		}
+	}
+	return true
+}
+
+// Sync diffs manifest against the organization's live workflows, threat
+// policies, and integration webhooks, returning the Plan Apply would
+// need to run to converge live state to the manifest.
+func Sync(ctx context.Context, client *sdk.Client, manifest *Manifest) (*Plan, error) {
+	plan := &Plan{}
+
+	if err := planWorkflows(ctx, client, manifest.Workflows, plan); err != nil {
+		return nil, fmt.Errorf("planning workflows: %w", err)
+	}
+	if err := planPolicies(ctx, client, manifest.Policies, plan); err != nil {
+		return nil, fmt.Errorf("planning policies: %w", err)
+	}
+	if err := planWebhooks(ctx, client, manifest.Webhooks, plan); err != nil {
+		return nil, fmt.Errorf("planning webhooks: %w", err)
+	}
+
+	return plan, nil
+}
+
+func planWorkflows(ctx context.Context, client *sdk.Client, defs []Definition, plan *Plan) error {
+	live, err := client.ListWorkflows(ctx)
+	if err != nil {
+		return fmt.Errorf("listing workflows: %w", err)
+	}
+
+	byName := make(map[string]sdk.Workflow, len(live))
+	for _, wf := range live {
+		byName[wf.Name] = wf
+	}
+
+	wanted := make(map[string]bool, len(defs))
+	for _, def := range defs {
+		wanted[def.Name] = true
+
+		existing, ok := byName[def.Name]
+		if !ok {
+			plan.Workflows = append(plan.Workflows, WorkflowAction{Type: ActionCreate, Key: def.Name, Definition: def})
+			continue
+		}
+
+		ex := existing
+		action := WorkflowAction{Key: def.Name, ID: ex.ID, Definition: def, Previous: &ex, Diffs: diffWorkflow(ex, def)}
+		if len(action.Diffs) == 0 {
+			action.Type = ActionNoop
+		} else {
+			action.Type = ActionUpdate
+		}
+		plan.Workflows = append(plan.Workflows, action)
+	}
+
+	for name, wf := range byName {
+		if wanted[name] {
+			continue
+		}
+		wf := wf
+		plan.Workflows = append(plan.Workflows, WorkflowAction{Type: ActionDelete, Key: name, ID: wf.ID, Previous: &wf})
+	}
+
+	return nil
+}
+
+func diffWorkflow(wf sdk.Workflow, def Definition) []FieldDiff {
+	var diffs []FieldDiff
+	diffs = appendDiff(diffs, diffString("type", wf.Type, def.Type))
+	if def.Status != "" {
+		diffs = appendDiff(diffs, diffString("status", wf.Status, def.Status))
+	}
+	if def.Config != nil {
+		diffs = appendDiff(diffs, diffJSON("config", wf.Config, def.Config))
+	}
+	return diffs
+}
+
+func planPolicies(ctx context.Context, client *sdk.Client, defs []PolicyDefinition, plan *Plan) error {
+	if len(defs) == 0 {
+		return nil
+	}
+
+	live, err := client.ListThreatPolicies(ctx, "")
+	if err != nil {
+		return fmt.Errorf("listing threat policies: %w", err)
+	}
+
+	byID := make(map[string]sdk.ThreatPolicy, len(live))
+	for _, p := range live {
+		byID[p.ID] = p
+	}
+
+	for _, def := range defs {
+		existing, ok := byID[def.ID]
+		if !ok {
+			return fmt.Errorf("policy %q: no such threat policy", def.ID)
+		}
+
+		ex := existing
+		action := PolicyAction{Key: def.ID, ID: def.ID, Definition: def, Previous: &ex}
+		if d := diffString("enabled", boolString(ex.Enabled), boolString(def.Enabled)); d != nil {
+			action.Type = ActionUpdate
+			action.Diffs = append(action.Diffs, *d)
+		} else {
+			action.Type = ActionNoop
+		}
+		plan.Policies = append(plan.Policies, action)
+	}
+
+	return nil
+}
+
+func planWebhooks(ctx context.Context, client *sdk.Client, defs []WebhookDefinition, plan *Plan) error {
+	live, err := client.ListIntegrationWebhooks(ctx, "")
+	if err != nil {
+		return fmt.Errorf("listing integration webhooks: %w", err)
+	}
+
+	byKey := make(map[string]sdk.IntegrationWebhook, len(live))
+	for _, wh := range live {
+		byKey[webhookKey(wh)] = wh
+	}
+
+	wanted := make(map[string]bool, len(defs))
+	for _, def := range defs {
+		key := def.key()
+		wanted[key] = true
+
+		existing, ok := byKey[key]
+		if !ok {
+			plan.Webhooks = append(plan.Webhooks, WebhookAction{Type: ActionCreate, Key: key, Definition: def})
+			continue
+		}
+
+		ex := existing
+		action := WebhookAction{Key: key, ID: ex.ID, Definition: def, Previous: &ex, Diffs: diffWebhook(ex, def)}
+		if len(action.Diffs) == 0 {
+			action.Type = ActionNoop
+		} else {
+			action.Type = ActionUpdate
+		}
+		plan.Webhooks = append(plan.Webhooks, action)
+	}
+
+	for key, wh := range byKey {
+		if wanted[key] {
+			continue
+		}
+		wh := wh
+		plan.Webhooks = append(plan.Webhooks, WebhookAction{Type: ActionDelete, Key: key, ID: wh.ID, Previous: &wh})
+	}
+
+	return nil
+}
+
+func diffWebhook(wh sdk.IntegrationWebhook, def WebhookDefinition) []FieldDiff {
+	var diffs []FieldDiff
+	diffs = appendDiff(diffs, diffString("vendor", wh.Vendor, def.Vendor))
+	if def.Status != "" {
+		diffs = appendDiff(diffs, diffString("status", wh.Status, def.Status))
+	}
+	if def.Config != nil {
+		diffs = appendDiff(diffs, diffJSON("config", wh.Config, mergedWebhookConfig(def)))
+	}
+	return diffs
+}
+
+// webhookKey returns the stable key identifying wh across Sync calls:
+// the "external_id" stashed in its Config, falling back to Name.
+func webhookKey(wh sdk.IntegrationWebhook) string {
+	if id, ok := wh.Config["external_id"].(string); ok && id != "" {
+		return id
+	}
+	return wh.Name
+}
+
+// mergedWebhookConfig returns def.Config with ExternalID stashed under
+// the "external_id" key, so a created or updated webhook can be
+// recognized by a later Sync call.
+func mergedWebhookConfig(def WebhookDefinition) map[string]interface{} {
+	cfg := make(map[string]interface{}, len(def.Config)+1)
+	for k, v := range def.Config {
+		cfg[k] = v
+	}
+	if def.ExternalID != "" {
+		cfg["external_id"] = def.ExternalID
+	}
+	return cfg
+}
+
+// diffJSON compares have and want by their JSON encodings, since
+// neither WorkflowConfig nor a webhook's free-form Config map defines
+// value equality of its own.
+func diffJSON(field string, have, want interface{}) *FieldDiff {
+	haveJSON, _ := json.Marshal(have)
+	wantJSON, _ := json.Marshal(want)
+	return diffString(field, string(haveJSON), string(wantJSON))
+}
+
+func boolString(b bool) string {
+	if b {
+		return "true"
+	}
+	return "false"
+}
@@ -0,0 +1,112 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package workflows
+
+import (
+	"testing"
+
+	"github.com/tektite-io/upwind-go-sdk/sdk"
+)
+
+func TestDiffWorkflowNoChanges(t *testing.T) {
+	live := sdk.Workflow{Name: "nightly-scan", Type: "notification", Status: "ENABLED"}
+	def := Definition{Name: "nightly-scan", Type: "notification", Status: "ENABLED"}
+
+	if diffs := diffWorkflow(live, def); len(diffs) != 0 {
+		t.Errorf("diffWorkflow() = %v, want no diffs", diffs)
+	}
+}
+
+func TestDiffWorkflowStatusChange(t *testing.T) {
+	live := sdk.Workflow{Name: "nightly-scan", Type: "notification", Status: "ENABLED"}
+	def := Definition{Name: "nightly-scan", Type: "notification", Status: "DISABLED"}
+
+	diffs := diffWorkflow(live, def)
+	if len(diffs) != 1 || diffs[0].Field != "status" {
+		t.Fatalf("diffWorkflow() = %v, want a single status diff", diffs)
+	}
+	if diffs[0].From != "ENABLED" || diffs[0].To != "DISABLED" {
+		t.Errorf("diffWorkflow() = %v", diffs[0])
+	}
+}
+
+func TestDiffWorkflowIgnoresEmptyDesiredStatus(t *testing.T) {
+	live := sdk.Workflow{Name: "nightly-scan", Type: "notification", Status: "ENABLED"}
+	def := Definition{Name: "nightly-scan", Type: "notification"}
+
+	if diffs := diffWorkflow(live, def); len(diffs) != 0 {
+		t.Errorf("diffWorkflow() = %v, want no diffs when Status is unset", diffs)
+	}
+}
+
+func TestWebhookDefinitionKey(t *testing.T) {
+	withExternalID := WebhookDefinition{ExternalID: "ext-1", Name: "slack-security"}
+	if got := withExternalID.key(); got != "ext-1" {
+		t.Errorf("key() = %q, want %q", got, "ext-1")
+	}
+
+	withoutExternalID := WebhookDefinition{Name: "slack-security"}
+	if got := withoutExternalID.key(); got != "slack-security" {
+		t.Errorf("key() = %q, want %q", got, "slack-security")
+	}
+}
+
+func TestWebhookKeyPrefersConfigExternalID(t *testing.T) {
+	wh := sdk.IntegrationWebhook{Name: "slack-security", Config: map[string]interface{}{"external_id": "ext-1"}}
+	if got := webhookKey(wh); got != "ext-1" {
+		t.Errorf("webhookKey() = %q, want %q", got, "ext-1")
+	}
+
+	wh = sdk.IntegrationWebhook{Name: "slack-security"}
+	if got := webhookKey(wh); got != "slack-security" {
+		t.Errorf("webhookKey() = %q, want %q", got, "slack-security")
+	}
+}
+
+func TestMergedWebhookConfig(t *testing.T) {
+	def := WebhookDefinition{ExternalID: "ext-1", Config: map[string]interface{}{"channel": "#security"}}
+
+	got := mergedWebhookConfig(def)
+	if got["channel"] != "#security" || got["external_id"] != "ext-1" {
+		t.Errorf("mergedWebhookConfig() = %v", got)
+	}
+
+	// The original Config must be left untouched.
+	if _, ok := def.Config["external_id"]; ok {
+		t.Error("mergedWebhookConfig() mutated def.Config")
+	}
+}
+
+func TestDiffWebhook(t *testing.T) {
+	live := sdk.IntegrationWebhook{Vendor: "slack", Status: "ENABLED"}
+	def := WebhookDefinition{Vendor: "pagerduty", Status: "ENABLED"}
+
+	diffs := diffWebhook(live, def)
+	if len(diffs) != 1 || diffs[0].Field != "vendor" {
+		t.Fatalf("diffWebhook() = %v, want a single vendor diff", diffs)
+	}
+}
+
+func TestPlanIsEmpty(t *testing.T) {
+	plan := &Plan{
+		Workflows: []WorkflowAction{{Type: ActionNoop}},
+		Policies:  []PolicyAction{{Type: ActionNoop}},
+		Webhooks:  []WebhookAction{{Type: ActionNoop}},
+	}
+	if !plan.IsEmpty() {
+		t.Error("IsEmpty() = false, want true for all-noop plan")
+	}
+
+	plan.Webhooks = append(plan.Webhooks, WebhookAction{Type: ActionCreate})
+	if plan.IsEmpty() {
+		t.Error("IsEmpty() = true, want false once a non-noop action is present")
+	}
+}
+
+func TestBoolString(t *testing.T) {
+	if boolString(true) != "true" || boolString(false) != "false" {
+		t.Error("boolString() did not round-trip true/false")
+	}
+}
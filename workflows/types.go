@@ -0,0 +1,62 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package workflows manages Workflows, ThreatPolicies, and
+// IntegrationWebhooks declaratively: author an upwind.yaml (or .hcl)
+// manifest describing the desired state, call Sync to diff it against
+// the live API, and Apply the resulting Plan, the same way Terraform
+// separates plan from apply.
+package workflows
+
+import "github.com/tektite-io/upwind-go-sdk/sdk"
+
+// Definition is a declarative description of a Workflow. Name is the
+// key Sync diffs against, since the Workflow API has no external_id
+// field of its own.
+type Definition struct {
+	Name string `yaml:"name"`
+	Type string `yaml:"type"`
+	// Status, if set, is validated the same way as
+	// sdk.UpdateWorkflowRequest.Status (sdk.StatusEnabled,
+	// sdk.StatusDisabled, or sdk.StatusArchived).
+	Status string              `yaml:"status,omitempty"`
+	Config *sdk.WorkflowConfig `yaml:"config,omitempty"`
+}
+
+// PolicyDefinition declares the desired Enabled state of a pre-existing
+// ThreatPolicy. Policies can't be created or deleted through the API,
+// so Sync only ever plans update actions for them, keyed by ID.
+type PolicyDefinition struct {
+	ID      string `yaml:"id"`
+	Enabled bool   `yaml:"enabled"`
+}
+
+// WebhookDefinition is a declarative description of an
+// IntegrationWebhook. ExternalID, if set, is stashed under the
+// "external_id" key of the webhook's Config (a free-form map, unlike
+// WorkflowConfig) so Sync can still recognize it after a rename; Name
+// is the key otherwise.
+type WebhookDefinition struct {
+	ExternalID string                 `yaml:"external_id,omitempty"`
+	Name       string                 `yaml:"name"`
+	Vendor     string                 `yaml:"vendor"`
+	Status     string                 `yaml:"status,omitempty"`
+	Config     map[string]interface{} `yaml:"config,omitempty"`
+}
+
+// key returns the key Sync matches d's live IntegrationWebhook against.
+func (d WebhookDefinition) key() string {
+	if d.ExternalID != "" {
+		return d.ExternalID
+	}
+	return d.Name
+}
+
+// Manifest is the full declarative state of an organization's
+// automation surface: the top-level document of an upwind.yaml file.
+type Manifest struct {
+	Workflows []Definition        `yaml:"workflows,omitempty"`
+	Policies  []PolicyDefinition  `yaml:"policies,omitempty"`
+	Webhooks  []WebhookDefinition `yaml:"webhooks,omitempty"`
+}